@@ -19,11 +19,64 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"os"
 
 	"github.com/suenchunyu/snow-lang/internal/repl"
+	"github.com/suenchunyu/snow-lang/internal/runner"
 )
 
+const usage = `Usage:
+    snow                                    start the interactive REPL
+    snow run [flags] <script> [args...]     evaluate a script file
+    snow [flags] <script> [args...]         shortcut for 'snow run'
+
+Flags:
+    -error-format human|json                how errors are reported (default human)
+`
+
 func main() {
-	repl.Start(os.Stdin, os.Stdout)
+	args := os.Args[1:]
+	if len(args) == 0 {
+		repl.Start(os.Stdin, os.Stdout)
+		return
+	}
+
+	switch args[0] {
+	case "run":
+		os.Exit(run(args[1:]))
+	case "help", "-h", "--help":
+		_, _ = fmt.Fprint(os.Stdout, usage)
+	default:
+		os.Exit(run(args))
+	}
+}
+
+func run(args []string) int {
+	flags := flag.NewFlagSet("run", flag.ContinueOnError)
+	flags.SetOutput(os.Stderr)
+	flags.Usage = func() {
+		_, _ = fmt.Fprint(os.Stderr, usage)
+	}
+	errorFormat := flags.String("error-format", "human", "how errors are reported")
+
+	// flag parsing stops at the script path, the remaining arguments
+	// belong to the script.
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	format, err := runner.ParseFormat(*errorFormat)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "snow: %s\n", err)
+		return 2
+	}
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	return runner.Run(flags.Arg(0), flags.Args()[1:], os.Stderr, format)
 }
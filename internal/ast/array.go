@@ -34,6 +34,10 @@ func (al *ArrayLiteral) TokenLiteral() string {
 	return al.Token.Literal
 }
 
+func (al *ArrayLiteral) Pos() token.Pos {
+	return al.Token.Pos()
+}
+
 func (al *ArrayLiteral) String() string {
 	var out bytes.Buffer
 	elements := make([]string, 0)
@@ -0,0 +1,57 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package ast
+
+import (
+	"bytes"
+
+	"github.com/suenchunyu/snow-lang/internal/token"
+)
+
+// AssignExpression assigns Value to Target, which is either an Identifier
+// or an IndexExpression. Operator is "=" or one of the compound forms like
+// "+=".
+type AssignExpression struct {
+	Token    *token.Token
+	Target   Expression
+	Operator string
+	Value    Expression
+}
+
+func (ae *AssignExpression) TokenLiteral() string {
+	return ae.Token.Literal
+}
+
+func (ae *AssignExpression) Pos() token.Pos {
+	return ae.Token.Pos()
+}
+
+func (ae *AssignExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(ae.Target.String())
+	out.WriteString(" " + ae.Operator + " ")
+	out.WriteString(ae.Value.String())
+
+	return out.String()
+}
+
+func (ae *AssignExpression) expressionNode() {
+	panic("implement me")
+}
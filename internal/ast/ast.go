@@ -18,12 +18,17 @@
 
 package ast
 
-import "bytes"
+import (
+	"bytes"
+
+	"github.com/suenchunyu/snow-lang/internal/token"
+)
 
 type (
 	Node interface {
 		TokenLiteral() string
 		String() string
+		Pos() token.Pos
 	}
 
 	Statement interface {
@@ -49,6 +54,13 @@ func (p *Program) TokenLiteral() string {
 	}
 }
 
+func (p *Program) Pos() token.Pos {
+	if len(p.Statements) > 0 {
+		return p.Statements[0].Pos()
+	}
+	return token.Pos{}
+}
+
 func (p *Program) String() string {
 	var out bytes.Buffer
 
@@ -33,6 +33,10 @@ func (bs *BlockStatement) TokenLiteral() string {
 	return bs.Token.Literal
 }
 
+func (bs *BlockStatement) Pos() token.Pos {
+	return bs.Token.Pos()
+}
+
 func (bs *BlockStatement) String() string {
 	var out bytes.Buffer
 	for _, stmt := range bs.Statements {
@@ -29,6 +29,10 @@ func (b *Boolean) TokenLiteral() string {
 	return b.Token.Literal
 }
 
+func (b *Boolean) Pos() token.Pos {
+	return b.Token.Pos()
+}
+
 func (b *Boolean) String() string {
 	return b.Token.Literal
 }
@@ -0,0 +1,61 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package ast
+
+import "github.com/suenchunyu/snow-lang/internal/token"
+
+type BreakStatement struct {
+	Token *token.Token
+}
+
+func (bs *BreakStatement) TokenLiteral() string {
+	return bs.Token.Literal
+}
+
+func (bs *BreakStatement) Pos() token.Pos {
+	return bs.Token.Pos()
+}
+
+func (bs *BreakStatement) String() string {
+	return bs.TokenLiteral() + ";"
+}
+
+func (bs *BreakStatement) statementNode() {
+	panic("implement me")
+}
+
+type ContinueStatement struct {
+	Token *token.Token
+}
+
+func (cs *ContinueStatement) TokenLiteral() string {
+	return cs.Token.Literal
+}
+
+func (cs *ContinueStatement) Pos() token.Pos {
+	return cs.Token.Pos()
+}
+
+func (cs *ContinueStatement) String() string {
+	return cs.TokenLiteral() + ";"
+}
+
+func (cs *ContinueStatement) statementNode() {
+	panic("implement me")
+}
@@ -29,12 +29,23 @@ type CallExpression struct {
 	Token     *token.Token
 	Function  Expression
 	Arguments []Expression
+
+	// Tail reports whether the value of the call is returned as is by the
+	// enclosing function.
+	Tail bool
 }
 
 func (ce *CallExpression) TokenLiteral() string {
 	return ce.Token.Literal
 }
 
+func (ce *CallExpression) Pos() token.Pos {
+	if ce.Function == nil {
+		return ce.Token.Pos()
+	}
+	return ce.Function.Pos()
+}
+
 func (ce *CallExpression) String() string {
 	var out bytes.Buffer
 
@@ -29,6 +29,10 @@ func (es *ExpressionStatement) TokenLiteral() string {
 	return es.Token.Literal
 }
 
+func (es *ExpressionStatement) Pos() token.Pos {
+	return es.Token.Pos()
+}
+
 func (es *ExpressionStatement) statementNode() {
 	panic("implement me")
 }
@@ -0,0 +1,42 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmafl.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package ast
+
+import "github.com/suenchunyu/snow-lang/internal/token"
+
+type FloatLiteral struct {
+	Token *token.Token
+	Value float64
+}
+
+func (fl *FloatLiteral) TokenLiteral() string {
+	return fl.Token.Literal
+}
+
+func (fl *FloatLiteral) Pos() token.Pos {
+	return fl.Token.Pos()
+}
+
+func (fl *FloatLiteral) String() string {
+	return fl.Token.Literal
+}
+
+func (fl *FloatLiteral) expressionNode() {
+	panic("implement me")
+}
@@ -26,32 +26,85 @@ import (
 )
 
 type FunctionLiteral struct {
-	Token      *token.Token
+	Token *token.Token
+	// Name is empty for anonymous functions.
+	Name       string
 	Parameters []*Identifier
-	Body       *BlockStatement
+	// Defaults holds the default value of each parameter, it's nil if no
+	// parameter has one and the entries of required parameters are nil.
+	Defaults []Expression
+	// Rest collects the remaining arguments of a variadic function.
+	Rest *Identifier
+	Body *BlockStatement
 }
 
 func (fl *FunctionLiteral) TokenLiteral() string {
 	return fl.Token.Literal
 }
 
+func (fl *FunctionLiteral) Pos() token.Pos {
+	return fl.Token.Pos()
+}
+
 func (fl *FunctionLiteral) String() string {
 	var out bytes.Buffer
 
-	params := make([]string, 0)
-	for _, param := range fl.Parameters {
-		params = append(params, param.String())
-	}
-
 	out.WriteString(fl.TokenLiteral())
+	if fl.Name != "" {
+		out.WriteString(" ")
+		out.WriteString(fl.Name)
+	}
 	out.WriteString("(")
-	out.WriteString(strings.Join(params, ","))
+	out.WriteString(FormatParameters(fl.Parameters, fl.Defaults, fl.Rest))
 	out.WriteString(") ")
 	out.WriteString(fl.Body.String())
 
 	return out.String()
 }
 
+// FormatParameters formats a parameter list like "x,y = 1,...rest".
+func FormatParameters(params []*Identifier, defaults []Expression, rest *Identifier) string {
+	out := make([]string, 0, len(params)+1)
+
+	for idx, param := range params {
+		if defaults != nil && defaults[idx] != nil {
+			out = append(out, param.String()+" = "+defaults[idx].String())
+			continue
+		}
+		out = append(out, param.String())
+	}
+
+	if rest != nil {
+		out = append(out, "..."+rest.String())
+	}
+
+	return strings.Join(out, ",")
+}
+
 func (fl *FunctionLiteral) expressionNode() {
 	panic("implement me")
 }
+
+// FunctionStatement declares a named function `fn Name(params) { body }`
+// in the current scope.
+type FunctionStatement struct {
+	Token    *token.Token
+	Name     *Identifier
+	Function *FunctionLiteral
+}
+
+func (fs *FunctionStatement) TokenLiteral() string {
+	return fs.Token.Literal
+}
+
+func (fs *FunctionStatement) Pos() token.Pos {
+	return fs.Token.Pos()
+}
+
+func (fs *FunctionStatement) String() string {
+	return fs.Function.String()
+}
+
+func (fs *FunctionStatement) statementNode() {
+	panic("implement me")
+}
@@ -0,0 +1,103 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package ast
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/suenchunyu/snow-lang/internal/token"
+)
+
+// ForStatement is the C-style loop `for (Init; Condition; Post) Body`, each
+// of Init, Condition and Post may be omitted.
+type ForStatement struct {
+	Token     *token.Token
+	Init      Statement
+	Condition Expression
+	Post      Expression
+	Body      *BlockStatement
+}
+
+func (fs *ForStatement) TokenLiteral() string {
+	return fs.Token.Literal
+}
+
+func (fs *ForStatement) Pos() token.Pos {
+	return fs.Token.Pos()
+}
+
+func (fs *ForStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("for (")
+	if fs.Init != nil {
+		// let statements print their own terminating semicolon.
+		out.WriteString(strings.TrimSuffix(fs.Init.String(), ";"))
+	}
+	out.WriteString("; ")
+	if fs.Condition != nil {
+		out.WriteString(fs.Condition.String())
+	}
+	out.WriteString("; ")
+	if fs.Post != nil {
+		out.WriteString(fs.Post.String())
+	}
+	out.WriteString(") ")
+	out.WriteString(fs.Body.String())
+
+	return out.String()
+}
+
+func (fs *ForStatement) statementNode() {
+	panic("implement me")
+}
+
+// ForInStatement is the loop `for Variable in Iterable Body`.
+type ForInStatement struct {
+	Token    *token.Token
+	Variable *Identifier
+	Iterable Expression
+	Body     *BlockStatement
+}
+
+func (fi *ForInStatement) TokenLiteral() string {
+	return fi.Token.Literal
+}
+
+func (fi *ForInStatement) Pos() token.Pos {
+	return fi.Token.Pos()
+}
+
+func (fi *ForInStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("for ")
+	out.WriteString(fi.Variable.String())
+	out.WriteString(" in ")
+	out.WriteString(fi.Iterable.String())
+	out.WriteString(" ")
+	out.WriteString(fi.Body.String())
+
+	return out.String()
+}
+
+func (fi *ForInStatement) statementNode() {
+	panic("implement me")
+}
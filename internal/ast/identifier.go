@@ -29,6 +29,10 @@ func (i *Identifier) TokenLiteral() string {
 	return i.Token.Literal
 }
 
+func (i *Identifier) Pos() token.Pos {
+	return i.Token.Pos()
+}
+
 func (i *Identifier) expressionNode() {
 	panic("implement me")
 }
@@ -35,6 +35,10 @@ func (ie *IfExpression) TokenLiteral() string {
 	return ie.Token.Literal
 }
 
+func (ie *IfExpression) Pos() token.Pos {
+	return ie.Token.Pos()
+}
+
 func (ie *IfExpression) String() string {
 	var out bytes.Buffer
 
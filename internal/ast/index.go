@@ -34,6 +34,10 @@ func (ie *IndexExpression) TokenLiteral() string {
 	return ie.Token.Literal
 }
 
+func (ie *IndexExpression) Pos() token.Pos {
+	return ie.Token.Pos()
+}
+
 func (ie *IndexExpression) String() string {
 	var out bytes.Buffer
 
@@ -49,3 +53,26 @@ func (ie *IndexExpression) String() string {
 func (ie *IndexExpression) expressionNode() {
 	panic("implement me")
 }
+
+// PropertyExpression accesses the property of an object `Object.Property`.
+type PropertyExpression struct {
+	Token    *token.Token
+	Object   Expression
+	Property *Identifier
+}
+
+func (pe *PropertyExpression) TokenLiteral() string {
+	return pe.Token.Literal
+}
+
+func (pe *PropertyExpression) Pos() token.Pos {
+	return pe.Token.Pos()
+}
+
+func (pe *PropertyExpression) String() string {
+	return "(" + pe.Object.String() + "." + pe.Property.String() + ")"
+}
+
+func (pe *PropertyExpression) expressionNode() {
+	panic("implement me")
+}
@@ -35,6 +35,10 @@ func (oe *InfixExpression) TokenLiteral() string {
 	return oe.Token.Literal
 }
 
+func (oe *InfixExpression) Pos() token.Pos {
+	return oe.Token.Pos()
+}
+
 func (oe *InfixExpression) String() string {
 	var out bytes.Buffer
 
@@ -18,17 +18,27 @@
 
 package ast
 
-import "github.com/suenchunyu/snow-lang/internal/token"
+import (
+	"math/big"
+
+	"github.com/suenchunyu/snow-lang/internal/token"
+)
 
 type IntegerLiteral struct {
 	Token *token.Token
 	Value int64
+	// Big holds the value of literals which don't fit into an int64.
+	Big *big.Int
 }
 
 func (il *IntegerLiteral) TokenLiteral() string {
 	return il.Token.Literal
 }
 
+func (il *IntegerLiteral) Pos() token.Pos {
+	return il.Token.Pos()
+}
+
 func (il *IntegerLiteral) String() string {
 	return il.Token.Literal
 }
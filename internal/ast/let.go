@@ -34,6 +34,10 @@ func (ls *LetStatement) TokenLiteral() string {
 	return ls.Token.Literal
 }
 
+func (ls *LetStatement) Pos() token.Pos {
+	return ls.Token.Pos()
+}
+
 func (ls *LetStatement) statementNode() {
 	panic("implement me")
 }
@@ -34,6 +34,10 @@ func (pe *PrefixExpression) TokenLiteral() string {
 	return pe.Token.Literal
 }
 
+func (pe *PrefixExpression) Pos() token.Pos {
+	return pe.Token.Pos()
+}
+
 func (pe *PrefixExpression) String() string {
 	var out bytes.Buffer
 
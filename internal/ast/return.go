@@ -33,6 +33,10 @@ func (rs *ReturnStatement) TokenLiteral() string {
 	return rs.Token.Literal
 }
 
+func (rs *ReturnStatement) Pos() token.Pos {
+	return rs.Token.Pos()
+}
+
 func (rs *ReturnStatement) statementNode() {
 	panic("implement me")
 }
@@ -29,6 +29,10 @@ func (sl *StringLiteral) TokenLiteral() string {
 	return sl.Token.Literal
 }
 
+func (sl *StringLiteral) Pos() token.Pos {
+	return sl.Token.Pos()
+}
+
 func (sl *StringLiteral) String() string {
 	return sl.Token.Literal
 }
@@ -0,0 +1,60 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package ast
+
+import (
+	"bytes"
+
+	"github.com/suenchunyu/snow-lang/internal/token"
+)
+
+// TemplateLiteral is a string embedding expressions `"a ${b} c"`. Parts holds
+// the text around the expressions, it has one element more than Expressions.
+type TemplateLiteral struct {
+	Token       *token.Token
+	Parts       []string
+	Expressions []Expression
+}
+
+func (tl *TemplateLiteral) TokenLiteral() string {
+	return tl.Token.Literal
+}
+
+func (tl *TemplateLiteral) Pos() token.Pos {
+	return tl.Token.Pos()
+}
+
+func (tl *TemplateLiteral) String() string {
+	var out bytes.Buffer
+
+	for idx, part := range tl.Parts {
+		out.WriteString(part)
+		if idx < len(tl.Expressions) {
+			out.WriteString("${")
+			out.WriteString(tl.Expressions[idx].String())
+			out.WriteString("}")
+		}
+	}
+
+	return out.String()
+}
+
+func (tl *TemplateLiteral) expressionNode() {
+	panic("implement me")
+}
@@ -0,0 +1,137 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package diag
+
+import (
+	"fmt"
+
+	"github.com/suenchunyu/snow-lang/internal/token"
+)
+
+type Severity uint8
+
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+	SeverityNote
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityNote:
+		return "note"
+	default:
+		return "error"
+	}
+}
+
+// Code identifies the kind of a diagnostic, it's stable across releases
+// so that tools can match on it instead of the message.
+type Code string
+
+const (
+	// lexical errors
+	CodeIllegalCharacter    Code = "illegal-character"
+	CodeUnterminatedComment Code = "unterminated-comment"
+	CodeMalformedNumber     Code = "malformed-number"
+	CodeUnterminatedString  Code = "unterminated-string"
+	CodeInvalidEscape       Code = "invalid-escape"
+
+	// syntax errors
+	CodeUnexpectedToken    Code = "unexpected-token"
+	CodeExpectedExpression Code = "expected-expression"
+	CodeInvalidInteger     Code = "invalid-integer"
+	CodeInvalidFloat       Code = "invalid-float"
+	CodeUnterminatedBlock  Code = "unterminated-block"
+	CodeInvalidAssignment  Code = "invalid-assignment-target"
+	CodeInvalidParameter   Code = "invalid-parameter"
+	CodeTooManyErrors      Code = "too-many-errors"
+
+	// runtime errors
+	CodeRuntime             Code = "runtime-error"
+	CodeTypeMismatch        Code = "type-mismatch"
+	CodeUnknownOperation    Code = "unknown-operation"
+	CodeUndefinedIdentifier Code = "undefined-identifier"
+	CodeUndefinedProperty   Code = "undefined-property"
+	CodeNotCallable         Code = "not-callable"
+	CodeArgument            Code = "invalid-argument"
+	CodeArity               Code = "wrong-arity"
+	CodeDivisionByZero      Code = "division-by-zero"
+	CodeIntegerOverflow     Code = "integer-overflow"
+	CodeInternal            Code = "internal-error"
+	CodeIndexOutOfRange     Code = "index-out-of-range"
+	CodeNotIterable         Code = "not-iterable"
+	CodeBranchOutsideLoop   Code = "branch-outside-loop"
+	CodeCancelled           Code = "cancelled"
+	CodeBudgetExceeded      Code = "budget-exceeded"
+	CodeCallDepth           Code = "call-depth-exceeded"
+)
+
+// Note points to a location related to a diagnostic.
+type Note struct {
+	Message string
+	Span    token.Span
+}
+
+// Fix suggests replacing the text covered by Span with Replacement.
+type Fix struct {
+	Message     string
+	Span        token.Span
+	Replacement string
+}
+
+type Diagnostic struct {
+	Severity Severity
+	Code     Code
+	Message  string
+	Span     token.Span
+	Related  []Note
+	Fix      *Fix
+	// Trace is the call stack of a runtime error, innermost call first. The
+	// message of each note is the name of the called function and its span
+	// is the call site.
+	Trace []Note
+}
+
+// Errorf returns an error diagnostic with the formatted message.
+func Errorf(code Code, span token.Span, format string, args ...interface{}) *Diagnostic {
+	return &Diagnostic{
+		Severity: SeverityError,
+		Code:     code,
+		Message:  fmt.Sprintf(format, args...),
+		Span:     span,
+	}
+}
+
+func (d *Diagnostic) Pos() token.Pos {
+	return d.Span.Start
+}
+
+// Error formats the diagnostic in the conventional "file:line:col: message"
+// form, which makes a Diagnostic usable as an error.
+func (d *Diagnostic) Error() string {
+	if !d.Span.Start.IsValid() {
+		return d.Message
+	}
+	return fmt.Sprintf("%s: %s", d.Span.Start, d.Message)
+}
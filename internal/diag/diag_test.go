@@ -0,0 +1,173 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package diag_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/suenchunyu/snow-lang/internal/diag"
+	"github.com/suenchunyu/snow-lang/internal/token"
+)
+
+func span(line, col, length int) token.Span {
+	return token.Span{
+		Start: token.Pos{File: "test.snow", Line: line, Column: col},
+		End:   token.Pos{File: "test.snow", Line: line, Column: col + length},
+	}
+}
+
+func TestDiagnosticError(t *testing.T) {
+	d := diag.Errorf(diag.CodeUnexpectedToken, span(2, 9, 1), "expected %s", ")")
+	if d.Error() != "test.snow:2:9: expected )" {
+		t.Errorf("d.Error() wrong. got = %q", d.Error())
+	}
+
+	d = diag.Errorf(diag.CodeRuntime, token.Span{}, "boom")
+	if d.Error() != "boom" {
+		t.Errorf("d.Error() wrong. got = %q", d.Error())
+	}
+}
+
+func TestRender(t *testing.T) {
+	source := "let x = 5;\nlet y = foo(x;"
+
+	d := diag.Errorf(diag.CodeUnexpectedToken, span(2, 14, 1), "expected next token to be ), got ; instead")
+	d.Related = []diag.Note{{Message: "call starts here", Span: span(2, 9, 3)}}
+	d.Fix = &diag.Fix{Message: "insert `)`", Span: span(2, 14, 0), Replacement: ")"}
+
+	var out bytes.Buffer
+	diag.Render(&out, source, []*diag.Diagnostic{d}, false)
+
+	expected := `error[unexpected-token]: expected next token to be ), got ; instead
+ --> test.snow:2:14
+  |
+2 | let y = foo(x;
+  |              ^
+  = note: call starts here (test.snow:2:9)
+  = help: insert ` + "`)`" + `
+`
+	if out.String() != expected {
+		t.Errorf("wrong output.\nexpected =\n%s\ngot =\n%s", expected, out.String())
+	}
+}
+
+func TestRenderUnderlinesSpan(t *testing.T) {
+	source := "\tlet héllo = 1;"
+
+	d := diag.Errorf(diag.CodeUndefinedIdentifier, span(1, 6, 6), "undefined identifier: héllo")
+
+	var out bytes.Buffer
+	diag.Render(&out, source, []*diag.Diagnostic{d}, false)
+
+	expected := `error[undefined-identifier]: undefined identifier: héllo
+ --> test.snow:1:6
+  |
+1 | 	let héllo = 1;
+  | 	    ^^^^^
+`
+	if out.String() != expected {
+		t.Errorf("wrong output.\nexpected =\n%s\ngot =\n%s", expected, out.String())
+	}
+}
+
+func TestRenderJSON(t *testing.T) {
+	diags := []*diag.Diagnostic{
+		diag.Errorf(diag.CodeIllegalCharacter, span(1, 9, 1), "illegal character %q", "@"),
+		diag.Errorf(diag.CodeUnexpectedToken, span(3, 1, 1), "expected next token to be IDENT, got = instead"),
+	}
+
+	var out bytes.Buffer
+	if err := diag.RenderJSON(&out, diags); err != nil {
+		t.Fatalf("RenderJSON returned error: %s", err)
+	}
+
+	dec := json.NewDecoder(&out)
+	for idx, d := range diags {
+		var got struct {
+			Severity string `json:"severity"`
+			Code     string `json:"code"`
+			Message  string `json:"message"`
+			Span     struct {
+				File  string `json:"file"`
+				Start struct {
+					Line   int `json:"line"`
+					Column int `json:"column"`
+				} `json:"start"`
+			} `json:"span"`
+		}
+		if err := dec.Decode(&got); err != nil {
+			t.Fatalf("diags[%d] - decode failed: %s", idx, err)
+		}
+
+		if got.Severity != "error" || got.Code != string(d.Code) || got.Message != d.Message {
+			t.Errorf("diags[%d] - wrong diagnostic. got = %+v", idx, got)
+		}
+
+		if got.Span.File != "test.snow" || got.Span.Start.Line != d.Span.Start.Line || got.Span.Start.Column != d.Span.Start.Column {
+			t.Errorf("diags[%d] - wrong span. got = %+v", idx, got.Span)
+		}
+	}
+}
+
+func TestRenderTrace(t *testing.T) {
+	source := "fn f(x) {\n  x + nope\n}\nf(1)"
+
+	d := diag.Errorf(diag.CodeUndefinedIdentifier, span(2, 7, 4), "undefined identifier: nope")
+	d.Trace = []diag.Note{{Message: "f", Span: span(4, 1, 0)}}
+
+	var out bytes.Buffer
+	diag.Render(&out, source, []*diag.Diagnostic{d}, false)
+
+	expected := `error[undefined-identifier]: undefined identifier: nope
+ --> test.snow:2:7
+  |
+2 |   x + nope
+  |       ^^^^
+    at f (test.snow:4:1)
+`
+	if out.String() != expected {
+		t.Errorf("wrong output.\nexpected =\n%s\ngot =\n%s", expected, out.String())
+	}
+}
+
+func TestRenderLongTrace(t *testing.T) {
+	d := diag.Errorf(diag.CodeRuntime, token.Span{}, "boom")
+	for line := 1; line <= 25; line++ {
+		d.Trace = append(d.Trace, diag.Note{Message: "f", Span: span(line, 1, 0)})
+	}
+
+	var out bytes.Buffer
+	diag.Render(&out, "", []*diag.Diagnostic{d}, false)
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 22 {
+		t.Fatalf("wrong number of lines, expected = 22, got = %d:\n%s", len(lines), out.String())
+	}
+
+	if lines[11] != "    ... 5 more frames" {
+		t.Errorf("elided frames not reported, got = %q", lines[11])
+	}
+
+	if lines[21] != "    at f (test.snow:25:1)" {
+		t.Errorf("outermost frame not rendered, got = %q", lines[21])
+	}
+}
@@ -0,0 +1,121 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package diag
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/suenchunyu/snow-lang/internal/token"
+)
+
+type (
+	jsonPos struct {
+		Offset int `json:"offset"`
+		Line   int `json:"line"`
+		Column int `json:"column"`
+	}
+
+	jsonSpan struct {
+		File  string  `json:"file,omitempty"`
+		Start jsonPos `json:"start"`
+		End   jsonPos `json:"end"`
+	}
+
+	jsonNote struct {
+		Message string   `json:"message"`
+		Span    jsonSpan `json:"span"`
+	}
+
+	jsonFix struct {
+		Message     string   `json:"message"`
+		Span        jsonSpan `json:"span"`
+		Replacement string   `json:"replacement"`
+	}
+
+	jsonDiagnostic struct {
+		Severity string     `json:"severity"`
+		Code     Code       `json:"code,omitempty"`
+		Message  string     `json:"message"`
+		Span     jsonSpan   `json:"span"`
+		Related  []jsonNote `json:"related,omitempty"`
+		Fix      *jsonFix   `json:"fix,omitempty"`
+		Trace    []jsonNote `json:"trace,omitempty"`
+	}
+)
+
+// RenderJSON writes diags to w as JSON lines, one object per diagnostic.
+func RenderJSON(w io.Writer, diags []*Diagnostic) error {
+	enc := json.NewEncoder(w)
+	for _, d := range diags {
+		if err := enc.Encode(toJSON(d)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toJSON(d *Diagnostic) *jsonDiagnostic {
+	out := &jsonDiagnostic{
+		Severity: d.Severity.String(),
+		Code:     d.Code,
+		Message:  d.Message,
+		Span:     toJSONSpan(d.Span),
+	}
+
+	for _, note := range d.Related {
+		out.Related = append(out.Related, jsonNote{
+			Message: note.Message,
+			Span:    toJSONSpan(note.Span),
+		})
+	}
+
+	for _, frame := range d.Trace {
+		out.Trace = append(out.Trace, jsonNote{
+			Message: frame.Message,
+			Span:    toJSONSpan(frame.Span),
+		})
+	}
+
+	if d.Fix != nil {
+		out.Fix = &jsonFix{
+			Message:     d.Fix.Message,
+			Span:        toJSONSpan(d.Fix.Span),
+			Replacement: d.Fix.Replacement,
+		}
+	}
+
+	return out
+}
+
+func toJSONSpan(span token.Span) jsonSpan {
+	return jsonSpan{
+		File: span.Start.File,
+		Start: jsonPos{
+			Offset: span.Start.Offset,
+			Line:   span.Start.Line,
+			Column: span.Start.Column,
+		},
+		End: jsonPos{
+			Offset: span.End.Offset,
+			Line:   span.End.Line,
+			Column: span.End.Column,
+		},
+	}
+}
@@ -0,0 +1,170 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package diag
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/suenchunyu/snow-lang/internal/color"
+	"github.com/suenchunyu/snow-lang/internal/token"
+)
+
+// Render writes diags in a human readable form to w. Each diagnostic is
+// followed by the offending line of source with the span underlined, and
+// colored with the ANSI palette when colored is set.
+func Render(w io.Writer, source string, diags []*Diagnostic, colored bool) {
+	r := &renderer{
+		w:       w,
+		lines:   strings.Split(source, "\n"),
+		colored: colored,
+	}
+	for _, d := range diags {
+		r.render(d)
+	}
+}
+
+type renderer struct {
+	w       io.Writer
+	lines   []string
+	colored bool
+}
+
+func (r *renderer) paint(style, text string) string {
+	if !r.colored || style == "" {
+		return text
+	}
+	return style + text + color.Reset
+}
+
+func (r *renderer) severityStyle(s Severity) string {
+	switch s {
+	case SeverityWarning:
+		return color.Yellow
+	case SeverityNote:
+		return color.Cyan
+	default:
+		return color.Red
+	}
+}
+
+func (r *renderer) render(d *Diagnostic) {
+	style := r.severityStyle(d.Severity)
+
+	header := d.Severity.String()
+	if d.Code != "" {
+		header += "[" + string(d.Code) + "]"
+	}
+	_, _ = fmt.Fprintf(r.w, "%s: %s\n", r.paint(style, header), r.paint(color.Bold, d.Message))
+
+	start := d.Span.Start
+	if !start.IsValid() {
+		r.renderTrace(d)
+		r.renderNotes(d)
+		return
+	}
+
+	gutter := strings.Repeat(" ", len(strconv.Itoa(start.Line)))
+	_, _ = fmt.Fprintf(r.w, "%s%s %s\n", gutter, r.paint(color.Blue, "-->"), start)
+
+	if start.Line <= len(r.lines) {
+		line := strings.TrimRight(r.lines[start.Line-1], "\r")
+		_, _ = fmt.Fprintf(r.w, "%s %s\n", gutter, r.paint(color.Blue, "|"))
+		_, _ = fmt.Fprintf(r.w, "%s %s %s\n", r.paint(color.Blue, strconv.Itoa(start.Line)), r.paint(color.Blue, "|"), line)
+		_, _ = fmt.Fprintf(r.w, "%s %s %s\n", gutter, r.paint(color.Blue, "|"), r.paint(style, underline(line, d.Span)))
+	}
+
+	r.renderTrace(d)
+	r.renderNotes(d)
+}
+
+// traceEdge is the number of innermost and outermost frames rendered of a
+// long trace, the frames in between are elided.
+const traceEdge = 10
+
+func (r *renderer) renderTrace(d *Diagnostic) {
+	for idx, frame := range d.Trace {
+		if len(d.Trace) > 2*traceEdge && idx >= traceEdge && idx < len(d.Trace)-traceEdge {
+			if idx == traceEdge {
+				_, _ = fmt.Fprintf(r.w, "    %s\n", r.paint(color.Gray, fmt.Sprintf("... %d more frames", len(d.Trace)-2*traceEdge)))
+			}
+			continue
+		}
+		_, _ = fmt.Fprintf(r.w, "    at %s (%s)\n", frame.Message, frame.Span.Start)
+	}
+}
+
+func (r *renderer) renderNotes(d *Diagnostic) {
+	gutter := " "
+	if d.Span.Start.IsValid() {
+		gutter = strings.Repeat(" ", len(strconv.Itoa(d.Span.Start.Line)))
+	}
+
+	for _, note := range d.Related {
+		if note.Span.Start.IsValid() {
+			_, _ = fmt.Fprintf(r.w, "%s %s %s (%s)\n", gutter, r.paint(color.Cyan, "= note:"), note.Message, note.Span.Start)
+		} else {
+			_, _ = fmt.Fprintf(r.w, "%s %s %s\n", gutter, r.paint(color.Cyan, "= note:"), note.Message)
+		}
+	}
+
+	if d.Fix != nil {
+		_, _ = fmt.Fprintf(r.w, "%s %s %s\n", gutter, r.paint(color.Green, "= help:"), d.Fix.Message)
+	}
+}
+
+// underline returns the caret marker for the part of line covered by span,
+// the marker is at least one column wide.
+func underline(line string, span token.Span) string {
+	from := clamp(span.Start.Column-1, 0, len(line))
+	to := len(line)
+	if span.End.Line == span.Start.Line {
+		to = clamp(span.End.Column-1, from, len(line))
+	}
+
+	width := utf8.RuneCountInString(line[from:to])
+	if width == 0 {
+		width = 1
+	}
+
+	var indent strings.Builder
+	for _, ch := range line[:from] {
+		// keep tabs so that the marker lines up with the source
+		if ch == '\t' {
+			indent.WriteRune('\t')
+		} else {
+			indent.WriteRune(' ')
+		}
+	}
+
+	return indent.String() + strings.Repeat("^", width)
+}
+
+func clamp(v, min, max int) int {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
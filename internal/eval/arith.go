@@ -0,0 +1,190 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package eval
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/suenchunyu/snow-lang/internal/diag"
+	"github.com/suenchunyu/snow-lang/internal/object"
+)
+
+// evalIntegerArithmetic evaluates the arithmetic operators on integers. A
+// result which overflows is promoted to a BigInt, unless checked arithmetic is
+// enabled which makes it an error.
+func (e *Evaluator) evalIntegerArithmetic(operator string, left, right int64) object.Object {
+	if right == 0 {
+		switch operator {
+		case "/":
+			return throw(diag.CodeDivisionByZero, "division by zero")
+		case "%":
+			return throw(diag.CodeDivisionByZero, "modulo by zero")
+		}
+	}
+
+	result, ok := arithmetic(operator, left, right)
+	if !ok {
+		if e.checked {
+			return throw(diag.CodeIntegerOverflow, "integer overflow: %d %s %d", left, operator, right)
+		}
+		return object.NewInteger(bigArithmetic(operator, big.NewInt(left), big.NewInt(right)))
+	}
+
+	return &object.Integer{Value: result}
+}
+
+// evalBigIntArithmetic evaluates the arithmetic operators on integers beyond
+// the range of an int64, the result is demoted to an Integer if it fits.
+func (e *Evaluator) evalBigIntArithmetic(operator string, left, right *big.Int) object.Object {
+	if right.Sign() == 0 {
+		switch operator {
+		case "/":
+			return throw(diag.CodeDivisionByZero, "division by zero")
+		case "%":
+			return throw(diag.CodeDivisionByZero, "modulo by zero")
+		}
+	}
+
+	result := bigArithmetic(operator, left, right)
+	if !result.IsInt64() && e.checked {
+		return throw(diag.CodeIntegerOverflow, "integer overflow: %s %s %s", left, operator, right)
+	}
+
+	return object.NewInteger(result)
+}
+
+// bigArithmetic is the counterpart of arithmetic for big integers, "/" and
+// "%" truncate towards zero like they do for int64.
+func bigArithmetic(operator string, left, right *big.Int) *big.Int {
+	switch operator {
+	case "+":
+		return new(big.Int).Add(left, right)
+	case "-":
+		return new(big.Int).Sub(left, right)
+	case "*":
+		return new(big.Int).Mul(left, right)
+	case "/":
+		return new(big.Int).Quo(left, right)
+	case "%":
+		return new(big.Int).Rem(left, right)
+	default:
+		panic("unknown arithmetic operator " + operator)
+	}
+}
+
+// arithmetic returns the wrapped result of the operation and reports whether
+// it fits into an int64. right must not be zero for "/" and "%".
+func arithmetic(operator string, left, right int64) (int64, bool) {
+	switch operator {
+	case "+":
+		result := left + right
+		return result, (right >= 0) == (result >= left)
+	case "-":
+		result := left - right
+		return result, (right <= 0) == (result >= left)
+	case "*":
+		if left == 0 || right == 0 {
+			return 0, true
+		}
+		result := left * right
+		if (left == -1 && right == math.MinInt64) || (right == -1 && left == math.MinInt64) {
+			return result, false
+		}
+		return result, result/right == left
+	case "/":
+		return left / right, !(left == math.MinInt64 && right == -1)
+	case "%":
+		return left % right, true
+	default:
+		panic("unknown arithmetic operator " + operator)
+	}
+}
+
+// evalFloatArithmetic evaluates the arithmetic operators on floats, "%" is
+// the remainder of the division truncated towards zero like for integers.
+func evalFloatArithmetic(operator string, left, right float64) object.Object {
+	switch operator {
+	case "+":
+		return &object.Float{Value: left + right}
+	case "-":
+		return &object.Float{Value: left - right}
+	case "*":
+		return &object.Float{Value: left * right}
+	case "/":
+		if right == 0 {
+			return throw(diag.CodeDivisionByZero, "division by zero")
+		}
+		return &object.Float{Value: left / right}
+	case "%":
+		if right == 0 {
+			return throw(diag.CodeDivisionByZero, "modulo by zero")
+		}
+		return &object.Float{Value: math.Mod(left, right)}
+	default:
+		panic("unknown arithmetic operator " + operator)
+	}
+}
+
+func isNumber(obj object.Object) bool {
+	switch obj.(type) {
+	case *object.Integer, *object.BigInt, *object.Float:
+		return true
+	default:
+		return false
+	}
+}
+
+func isInteger(obj object.Object) bool {
+	switch obj.(type) {
+	case *object.Integer, *object.BigInt:
+		return true
+	default:
+		return false
+	}
+}
+
+// toBigInt returns the value of an integer as a big.Int, which must not be
+// modified.
+func toBigInt(obj object.Object) *big.Int {
+	switch obj := obj.(type) {
+	case *object.Integer:
+		return big.NewInt(obj.Value)
+	case *object.BigInt:
+		return obj.Value
+	default:
+		panic("not an integer: " + obj.Type().String())
+	}
+}
+
+// toFloat returns the value of a number as a float, integers beyond 2^53
+// may lose precision.
+func toFloat(obj object.Object) float64 {
+	switch obj := obj.(type) {
+	case *object.Integer:
+		return float64(obj.Value)
+	case *object.BigInt:
+		f, _ := new(big.Float).SetInt(obj.Value).Float64()
+		return f
+	case *object.Float:
+		return obj.Value
+	default:
+		panic("not a number: " + obj.Type().String())
+	}
+}
@@ -0,0 +1,118 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package eval
+
+import (
+	"math/big"
+
+	"github.com/suenchunyu/snow-lang/internal/diag"
+	"github.com/suenchunyu/snow-lang/internal/object"
+)
+
+// maxShift bounds the count of left shifts, so that a script can't allocate
+// a huge integer by mistake.
+const maxShift = 1 << 20
+
+// evalIntegerBitwise evaluates the bitwise and shift operators on integers.
+// Right shifts are arithmetic, left shifts which overflow are promoted to a
+// BigInt like the arithmetic operators.
+func (e *Evaluator) evalIntegerBitwise(operator string, left, right int64) object.Object {
+	switch operator {
+	case "&":
+		return &object.Integer{Value: left & right}
+	case "|":
+		return &object.Integer{Value: left | right}
+	case "^":
+		return &object.Integer{Value: left ^ right}
+	}
+
+	if err := checkShift(right); err != nil {
+		return err
+	}
+
+	if operator == ">>" {
+		return &object.Integer{Value: left >> uint(right)}
+	}
+
+	if right < 64 && (left<<uint(right))>>uint(right) == left {
+		return &object.Integer{Value: left << uint(right)}
+	}
+	if e.checked {
+		return throw(diag.CodeIntegerOverflow, "integer overflow: %d << %d", left, right)
+	}
+	return object.NewInteger(new(big.Int).Lsh(big.NewInt(left), uint(right)))
+}
+
+// evalBigIntBitwise is the counterpart of evalIntegerBitwise for integers of
+// which at least one is a BigInt, the operators behave as if integers were
+// represented in two's complement with an infinite number of bits.
+func (e *Evaluator) evalBigIntBitwise(operator string, left, right *big.Int) object.Object {
+	switch operator {
+	case "&":
+		return object.NewInteger(new(big.Int).And(left, right))
+	case "|":
+		return object.NewInteger(new(big.Int).Or(left, right))
+	case "^":
+		return object.NewInteger(new(big.Int).Xor(left, right))
+	}
+
+	if !right.IsInt64() {
+		if right.Sign() < 0 {
+			return throw(diag.CodeRuntime, "negative shift count: %s", right)
+		}
+		return throw(diag.CodeRuntime, "shift count too large: %s", right)
+	}
+
+	count := right.Int64()
+	if err := checkShift(count); err != nil {
+		return err
+	}
+
+	if operator == ">>" {
+		return object.NewInteger(new(big.Int).Rsh(left, uint(count)))
+	}
+
+	result := new(big.Int).Lsh(left, uint(count))
+	if e.checked && !result.IsInt64() {
+		return throw(diag.CodeIntegerOverflow, "integer overflow: %s << %d", left, count)
+	}
+	return object.NewInteger(result)
+}
+
+func checkShift(count int64) *object.Error {
+	if count < 0 {
+		return throw(diag.CodeRuntime, "negative shift count: %d", count)
+	}
+	if count > maxShift {
+		return throw(diag.CodeRuntime, "shift count too large: %d", count)
+	}
+	return nil
+}
+
+// evalTildeOperatorExpression evaluates the bitwise complement `~x`.
+func evalTildeOperatorExpression(right object.Object) object.Object {
+	switch right := right.(type) {
+	case *object.Integer:
+		return &object.Integer{Value: ^right.Value}
+	case *object.BigInt:
+		return object.NewInteger(new(big.Int).Not(right.Value))
+	default:
+		return throw(diag.CodeUnknownOperation, "unknown operation: ~%s", right.Type())
+	}
+}
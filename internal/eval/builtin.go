@@ -18,23 +18,115 @@
 
 package eval
 
-import "github.com/suenchunyu/snow-lang/internal/object"
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/suenchunyu/snow-lang/internal/diag"
+	"github.com/suenchunyu/snow-lang/internal/object"
+)
 
 var builtin = map[string]*object.Builtin{
-	"len": {builtinFunctionLen()},
+	"len":   {Fn: builtinFunctionLen()},
+	"print": {Fn: builtinFunctionPrint()},
+	"int":   {Fn: builtinFunctionInt()},
+	"float": {Fn: builtinFunctionFloat()},
 }
 
 func builtinFunctionLen() object.BuiltinFunction {
 	return func(args ...object.Object) object.Object {
 		if len(args) != 1 {
-			return throw("wrong number of arguments. got %d, want 1", len(args))
+			return throw(diag.CodeArgument, "wrong number of arguments. got %d, want 1", len(args))
 		}
 
 		switch arg := args[0].(type) {
 		case *object.String:
 			return &object.Integer{Value: int64(len(arg.Value))}
+		case *object.Array:
+			return &object.Integer{Value: int64(len(arg.Elements))}
+		case *object.Hash:
+			return &object.Integer{Value: int64(arg.Len())}
+		default:
+			return throw(diag.CodeArgument, "argument type to `len` not supported")
+		}
+	}
+}
+
+// builtinFunctionInt converts a number or a string to an integer, floats are
+// truncated towards zero. Values beyond the range of an int64 are BigInts.
+func builtinFunctionInt() object.BuiltinFunction {
+	return func(args ...object.Object) object.Object {
+		if len(args) != 1 {
+			return throw(diag.CodeArgument, "wrong number of arguments. got %d, want 1", len(args))
+		}
+
+		switch arg := args[0].(type) {
+		case *object.Integer, *object.BigInt:
+			return arg
+		case *object.Float:
+			if math.IsNaN(arg.Value) || math.IsInf(arg.Value, 0) {
+				return throw(diag.CodeArgument, "cannot convert %s to integer", arg.Inspect())
+			}
+			value, _ := big.NewFloat(arg.Value).Int(nil)
+			return object.NewInteger(value)
+		case *object.String:
+			value, ok := new(big.Int).SetString(strings.TrimSpace(arg.Value), 10)
+			if !ok {
+				return throw(diag.CodeArgument, "could not parse %q as integer", arg.Value)
+			}
+			return object.NewInteger(value)
+		default:
+			return throw(diag.CodeArgument, "argument type to `int` not supported, got %s", arg.Type())
+		}
+	}
+}
+
+// builtinFunctionFloat converts a number or a string to a float.
+func builtinFunctionFloat() object.BuiltinFunction {
+	return func(args ...object.Object) object.Object {
+		if len(args) != 1 {
+			return throw(diag.CodeArgument, "wrong number of arguments. got %d, want 1", len(args))
+		}
+
+		switch arg := args[0].(type) {
+		case *object.Integer, *object.BigInt:
+			return &object.Float{Value: toFloat(arg)}
+		case *object.Float:
+			return arg
+		case *object.String:
+			value, err := strconv.ParseFloat(strings.TrimSpace(arg.Value), 64)
+			if err != nil {
+				return throw(diag.CodeArgument, "could not parse %q as float", arg.Value)
+			}
+			return &object.Float{Value: value}
 		default:
-			return throw("argument type to `len` not supported")
+			return throw(diag.CodeArgument, "argument type to `float` not supported, got %s", arg.Type())
 		}
 	}
 }
+
+func builtinFunctionPrint() object.BuiltinFunction {
+	return func(args ...object.Object) object.Object {
+		return printObjects(os.Stdout, args)
+	}
+}
+
+// NewPrint returns a `print` builtin which writes to w instead of the
+// standard output, it's meant to be bound in the global environment.
+func NewPrint(w io.Writer) *object.Builtin {
+	return &object.Builtin{Fn: func(args ...object.Object) object.Object {
+		return printObjects(w, args)
+	}}
+}
+
+func printObjects(w io.Writer, args []object.Object) object.Object {
+	for _, arg := range args {
+		_, _ = fmt.Fprintln(w, arg.Inspect())
+	}
+	return Null
+}
@@ -20,9 +20,14 @@ package eval
 
 import (
 	"fmt"
+	"math"
+	"math/big"
+	"strings"
 
 	"github.com/suenchunyu/snow-lang/internal/ast"
+	"github.com/suenchunyu/snow-lang/internal/diag"
 	"github.com/suenchunyu/snow-lang/internal/object"
+	"github.com/suenchunyu/snow-lang/internal/token"
 )
 
 var (
@@ -31,47 +36,74 @@ var (
 	False = &object.Boolean{Value: false}
 )
 
-func Eval(node ast.Node, env *object.Environment) object.Object {
+func (e *Evaluator) eval(node ast.Node, env *object.Environment) object.Object {
+	result := e.evalNode(node, env)
+
+	// errors are positioned at the innermost node they are raised from.
+	if err, ok := result.(*object.Error); ok && !err.Pos.IsValid() {
+		err.Pos = node.Pos()
+	}
+
+	return result
+}
+
+func (e *Evaluator) evalNode(node ast.Node, env *object.Environment) object.Object {
 	switch node := node.(type) {
 	case *ast.Program:
-		return evalProgram(node, env)
+		return e.evalProgram(node, env)
 	case *ast.ExpressionStatement:
-		return Eval(node.Expression, env)
+		return e.eval(node.Expression, env)
 	case *ast.IntegerLiteral:
+		if node.Big != nil {
+			return &object.BigInt{Value: node.Big}
+		}
 		return &object.Integer{Value: node.Value}
+	case *ast.FloatLiteral:
+		return &object.Float{Value: node.Value}
 	case *ast.Boolean:
 		return nativeBoolToBooleanObject(node.Value)
 	case *ast.PrefixExpression:
-		right := Eval(node.Right, env)
+		right := e.eval(node.Right, env)
 		if isError(right) {
 			return right
 		}
-		return evalPrefixExpression(node.Operator, right)
+		return e.evalPrefixExpression(node.Operator, right)
 	case *ast.InfixExpression:
-		left := Eval(node.Left, env)
+		if node.Operator == "&&" || node.Operator == "||" {
+			return e.evalLogicalExpression(node, env)
+		}
+
+		left := e.eval(node.Left, env)
 		if isError(left) {
 			return left
 		}
-		right := Eval(node.Right, env)
+		right := e.eval(node.Right, env)
 		if isError(right) {
 			return right
 		}
-		return evalInfixExpression(node.Operator, left, right)
+		return e.evalInfixExpression(node.Operator, left, right)
 	case *ast.IfExpression:
-		return evalIfExpression(node, env)
+		return e.evalIfExpression(node, env)
 	case *ast.BlockStatement:
-		return evalBlockStatement(node, env)
+		return e.evalBlockStatement(node, env)
 	case *ast.ReturnStatement:
-		val := Eval(node.ReturnValue, env)
+		val := e.eval(node.ReturnValue, env)
 		if isError(val) {
 			return val
 		}
 		return &object.ReturnValue{Value: val}
 	case *ast.LetStatement:
-		val := Eval(node.Value, env)
+		val := e.eval(node.Value, env)
 		if isError(val) {
 			return val
 		}
+
+		// `let add = fn() {}` names the function for error messages.
+		if fn, ok := val.(*object.Function); ok && fn.Name == "" {
+			if _, ok := node.Value.(*ast.FunctionLiteral); ok {
+				fn.Name = node.Name.Value
+			}
+		}
 		env.Set(node.Name.Value, val)
 	case *ast.Identifier:
 		return evalIdentifier(node, env)
@@ -79,26 +111,372 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		params := node.Parameters
 		body := node.Body
 		return &object.Function{
+			Name:       node.Name,
 			Env:        env,
 			Body:       body,
 			Parameters: params,
+			Defaults:   node.Defaults,
+			Rest:       node.Rest,
 		}
+	case *ast.FunctionStatement:
+		// the function is bound in the scope it closes over, so it can
+		// call itself recursively.
+		fn := e.eval(node.Function, env)
+		env.Set(node.Name.Value, fn)
 	case *ast.CallExpression:
-		function := Eval(node.Function, env)
+		function := e.eval(node.Function, env)
 		if isError(function) {
 			return function
 		}
-		args := evalExpressions(node.Arguments, env)
+		args := e.evalExpressions(node.Arguments, env)
 		if len(args) == 1 && isError(args[0]) {
 			return args[0]
 		}
-		return applyFunction(function, args)
+		if fn, ok := function.(*object.Function); ok && node.Tail {
+			return &object.TailCall{Function: fn, Arguments: args, Pos: node.Pos()}
+		}
+		return e.applyFunction(function, args, node.Pos())
 	case *ast.StringLiteral:
 		return &object.String{Value: node.Value}
+	case *ast.TemplateLiteral:
+		return e.evalTemplateLiteral(node, env)
+	case *ast.ArrayLiteral:
+		elements := e.evalExpressions(node.Elements, env)
+		if len(elements) == 1 && isError(elements[0]) {
+			return elements[0]
+		}
+		return &object.Array{Elements: elements}
+	case *ast.IndexExpression:
+		left := e.eval(node.Left, env)
+		if isError(left) {
+			return left
+		}
+		index := e.eval(node.Index, env)
+		if isError(index) {
+			return index
+		}
+		return evalIndexExpression(left, index)
+	case *ast.PropertyExpression:
+		obj := e.eval(node.Object, env)
+		if isError(obj) {
+			return obj
+		}
+		return evalPropertyExpression(obj, node.Property.Value)
+	case *ast.HashLiteral:
+		return e.evalHashLiteral(node, env)
+	case *ast.AssignExpression:
+		return e.evalAssignExpression(node, env)
+	case *ast.ForStatement:
+		return e.evalForStatement(node, env)
+	case *ast.ForInStatement:
+		return e.evalForInStatement(node, env)
+	case *ast.WhileStatement:
+		return e.evalWhileStatement(node, env)
+	case *ast.BreakStatement:
+		return &object.Break{Pos: node.Pos()}
+	case *ast.ContinueStatement:
+		return &object.Continue{Pos: node.Pos()}
 	}
 	return nil
 }
 
+func (e *Evaluator) evalForStatement(node *ast.ForStatement, env *object.Environment) object.Object {
+	// the loop variables declared by Init are scoped to the loop.
+	env = object.NewEnclosedEnv(env)
+
+	if node.Init != nil {
+		init := e.eval(node.Init, env)
+		if isError(init) {
+			return init
+		}
+	}
+
+	for {
+		if node.Condition != nil {
+			condition := e.eval(node.Condition, env)
+			if isError(condition) {
+				return condition
+			}
+			if !isTruthy(condition) {
+				return nil
+			}
+		}
+
+		if result, done := e.evalLoopBody(node.Body, env); done {
+			return result
+		}
+
+		if node.Post != nil {
+			post := e.eval(node.Post, env)
+			if isError(post) {
+				return post
+			}
+		}
+	}
+}
+
+func (e *Evaluator) evalWhileStatement(node *ast.WhileStatement, env *object.Environment) object.Object {
+	for {
+		condition := e.eval(node.Condition, env)
+		if isError(condition) {
+			return condition
+		}
+		if !isTruthy(condition) {
+			return nil
+		}
+
+		if result, done := e.evalLoopBody(node.Body, env); done {
+			return result
+		}
+	}
+}
+
+func (e *Evaluator) evalForInStatement(node *ast.ForInStatement, env *object.Environment) object.Object {
+	iterable := e.eval(node.Iterable, env)
+	if isError(iterable) {
+		return iterable
+	}
+
+	var items []object.Object
+	switch iterable := iterable.(type) {
+	case *object.Array:
+		// iterate over a copy, so that assignments in the body don't
+		// change the elements being visited.
+		items = append(items, iterable.Elements...)
+	case *object.Hash:
+		for _, pair := range iterable.Pairs() {
+			items = append(items, pair.Key)
+		}
+	case *object.String:
+		for _, r := range iterable.Value {
+			items = append(items, &object.String{Value: string(r)})
+		}
+	default:
+		return throw(diag.CodeNotIterable, "cannot iterate over %s", iterable.Type())
+	}
+
+	for _, item := range items {
+		scope := object.NewEnclosedEnv(env)
+		scope.Set(node.Variable.Value, item)
+
+		if result, done := e.evalLoopBody(node.Body, scope); done {
+			return result
+		}
+	}
+
+	return nil
+}
+
+// evalLoopBody evaluates one iteration of a loop, done reports whether the
+// loop has to stop and result is what the loop statement evaluates to then.
+func (e *Evaluator) evalLoopBody(body *ast.BlockStatement, env *object.Environment) (result object.Object, done bool) {
+	if err := e.step(); err != nil {
+		return err, true
+	}
+
+	result = e.eval(body, env)
+
+	switch result.(type) {
+	case *object.Break:
+		return nil, true
+	case *object.ReturnValue, *object.Error:
+		return result, true
+	default:
+		return nil, false
+	}
+}
+
+// escapedBranch turns a break or continue which reached a function or program
+// boundary into an error.
+func escapedBranch(obj object.Object) object.Object {
+	switch obj := obj.(type) {
+	case *object.Break:
+		err := throw(diag.CodeBranchOutsideLoop, "break outside loop")
+		err.Pos = obj.Pos
+		return err
+	case *object.Continue:
+		err := throw(diag.CodeBranchOutsideLoop, "continue outside loop")
+		err.Pos = obj.Pos
+		return err
+	default:
+		return obj
+	}
+}
+
+func (e *Evaluator) evalAssignExpression(node *ast.AssignExpression, env *object.Environment) object.Object {
+	switch target := node.Target.(type) {
+	case *ast.Identifier:
+		var current object.Object
+		if node.Operator != "=" {
+			current = evalIdentifier(target, env)
+			if isError(current) {
+				return current
+			}
+		}
+
+		value := e.evalAssignedValue(node, current, env)
+		if isError(value) {
+			return value
+		}
+
+		if _, ok := env.Assign(target.Value, value); !ok {
+			return throw(diag.CodeUndefinedIdentifier, "assignment to undeclared identifier: %s", target.Value)
+		}
+		return value
+	case *ast.IndexExpression:
+		left := e.eval(target.Left, env)
+		if isError(left) {
+			return left
+		}
+		index := e.eval(target.Index, env)
+		if isError(index) {
+			return index
+		}
+
+		var current object.Object
+		if node.Operator != "=" {
+			current = evalIndexExpression(left, index)
+			if isError(current) {
+				return current
+			}
+		}
+
+		value := e.evalAssignedValue(node, current, env)
+		if isError(value) {
+			return value
+		}
+		return evalIndexAssignment(left, index, value)
+	default:
+		return throw(diag.CodeInvalidAssignment, "invalid assignment target: %s", node.Target.String())
+	}
+}
+
+// evalAssignedValue evaluates the right hand side of an assignment, combined
+// with the current value of the target for compound assignments like "+=".
+func (e *Evaluator) evalAssignedValue(node *ast.AssignExpression, current object.Object, env *object.Environment) object.Object {
+	value := e.eval(node.Value, env)
+	if isError(value) || node.Operator == "=" {
+		return value
+	}
+
+	operator := strings.TrimSuffix(node.Operator, "=")
+	return e.evalInfixExpression(operator, current, value)
+}
+
+func evalIndexAssignment(left, index, value object.Object) object.Object {
+	switch left := left.(type) {
+	case *object.Array:
+		if large, ok := index.(*object.BigInt); ok {
+			return throw(diag.CodeIndexOutOfRange, "index out of range: %s (length %d)", large.Inspect(), len(left.Elements))
+		}
+
+		idx, ok := index.(*object.Integer)
+		if !ok {
+			return throw(diag.CodeTypeMismatch, "array index must be %s, got %s", object.TypeInteger, index.Type())
+		}
+
+		if idx.Value < 0 || idx.Value >= int64(len(left.Elements)) {
+			return throw(diag.CodeIndexOutOfRange, "index out of range: %d (length %d)", idx.Value, len(left.Elements))
+		}
+
+		left.Elements[idx.Value] = value
+		return value
+	case *object.Hash:
+		key, ok := index.(object.Hashable)
+		if !ok {
+			return throw(diag.CodeTypeMismatch, "unusable as hash key: %s", index.Type())
+		}
+
+		left.Set(key, value)
+		return value
+	default:
+		return throw(diag.CodeUnknownOperation, "index assignment not supported: %s", left.Type())
+	}
+}
+
+func (e *Evaluator) evalHashLiteral(node *ast.HashLiteral, env *object.Environment) object.Object {
+	hash := object.NewHash()
+
+	for _, pair := range node.Pairs {
+		key := e.eval(pair.Key, env)
+		if isError(key) {
+			return key
+		}
+
+		hashKey, ok := key.(object.Hashable)
+		if !ok {
+			return throw(diag.CodeTypeMismatch, "unusable as hash key: %s", key.Type())
+		}
+
+		value := e.eval(pair.Value, env)
+		if isError(value) {
+			return value
+		}
+
+		hash.Set(hashKey, value)
+	}
+
+	return hash
+}
+
+func evalIndexExpression(left, index object.Object) object.Object {
+	switch left := left.(type) {
+	case *object.Array:
+		return evalArrayIndexExpression(left, index)
+	case *object.Hash:
+		return evalHashIndexExpression(left, index)
+	default:
+		return throw(diag.CodeUnknownOperation, "index operator not supported: %s", left.Type())
+	}
+}
+
+// evalPropertyExpression evaluates `obj.name`, for hashes it's the same as
+// `obj["name"]`.
+func evalPropertyExpression(obj object.Object, name string) object.Object {
+	switch obj := obj.(type) {
+	case *object.Hash:
+		return evalHashIndexExpression(obj, &object.String{Value: name})
+	case object.PropertyAccessor:
+		value, ok := obj.Property(name)
+		if !ok {
+			return throw(diag.CodeUndefinedProperty, "undefined property: %s", name)
+		}
+		return value
+	default:
+		return throw(diag.CodeUnknownOperation, "property access not supported: %s", obj.Type())
+	}
+}
+
+func evalHashIndexExpression(hash *object.Hash, index object.Object) object.Object {
+	key, ok := index.(object.Hashable)
+	if !ok {
+		return throw(diag.CodeTypeMismatch, "unusable as hash key: %s", index.Type())
+	}
+
+	value, ok := hash.Get(key)
+	if !ok {
+		return Null
+	}
+	return value
+}
+
+func evalArrayIndexExpression(array *object.Array, index object.Object) object.Object {
+	if large, ok := index.(*object.BigInt); ok {
+		return throw(diag.CodeIndexOutOfRange, "index out of range: %s (length %d)", large.Inspect(), len(array.Elements))
+	}
+
+	idx, ok := index.(*object.Integer)
+	if !ok {
+		return throw(diag.CodeTypeMismatch, "array index must be %s, got %s", object.TypeInteger, index.Type())
+	}
+
+	if idx.Value < 0 || idx.Value >= int64(len(array.Elements)) {
+		return throw(diag.CodeIndexOutOfRange, "index out of range: %d (length %d)", idx.Value, len(array.Elements))
+	}
+
+	return array.Elements[idx.Value]
+}
+
 func nativeBoolToBooleanObject(input bool) object.Object {
 	if input {
 		return True
@@ -106,42 +484,143 @@ func nativeBoolToBooleanObject(input bool) object.Object {
 	return False
 }
 
-func applyFunction(fn object.Object, args []object.Object) object.Object {
+// applyFunction calls fn with args, pos is the position of the call.
+func (e *Evaluator) applyFunction(fn object.Object, args []object.Object, pos token.Pos) object.Object {
+	if err := e.step(); err != nil {
+		return err
+	}
+
 	switch fn := fn.(type) {
 	case *object.Function:
-		extended := extendFunctionEnv(fn, args)
-		evaluated := Eval(fn.Body, extended)
-		return unwrapReturnValue(evaluated)
+		if len(e.stack) >= e.maxDepth {
+			return throw(diag.CodeCallDepth, "maximum call depth exceeded (%d)", e.maxDepth)
+		}
+		e.stack = append(e.stack, object.Frame{Function: functionName(fn), Pos: pos})
+
+		result := e.callFunction(fn, args)
+		// tail calls replace the frame of the function making them, so
+		// that recursion in tail position runs in constant stack space.
+		for {
+			call, ok := result.(*object.TailCall)
+			if !ok {
+				break
+			}
+			if err := e.step(); err != nil {
+				result = err
+				break
+			}
+			e.stack[len(e.stack)-1] = object.Frame{Function: functionName(call.Function), Pos: call.Pos}
+			result = e.callFunction(call.Function, call.Arguments)
+		}
+
+		// the innermost call an error passes through sees the whole stack.
+		if err, ok := result.(*object.Error); ok && err.Trace == nil {
+			err.Trace = e.trace()
+		}
+
+		e.stack = e.stack[:len(e.stack)-1]
+		return result
 	case *object.Builtin:
 		return fn.Fn(args...)
 	default:
-		return throw("not a function: %s", fn.Type())
+		return throw(diag.CodeNotCallable, "not a function: %s", fn.Type())
 	}
+}
 
+// callFunction evaluates the body of fn with args bound to its parameters.
+func (e *Evaluator) callFunction(fn *object.Function, args []object.Object) object.Object {
+	extended, err := e.extendFunctionEnv(fn, args)
+	if err != nil {
+		return err
+	}
+	evaluated := e.eval(fn.Body, extended)
+	return unwrapReturnValue(escapedBranch(evaluated))
 }
 
-func extendFunctionEnv(fn *object.Function, args []object.Object) *object.Environment {
+// extendFunctionEnv binds the arguments of a call to the parameters of fn.
+// Missing arguments take the default value of their parameter, evaluated in
+// the new scope so that it may refer to the parameters before it.
+func (e *Evaluator) extendFunctionEnv(fn *object.Function, args []object.Object) (*object.Environment, *object.Error) {
+	if err := checkArity(fn, len(args)); err != nil {
+		return nil, err
+	}
+
 	env := object.NewEnclosedEnv(fn.Env)
 
 	for idx, param := range fn.Parameters {
-		env.Set(param.Value, args[idx])
+		if idx < len(args) {
+			env.Set(param.Value, args[idx])
+			continue
+		}
+
+		val := e.eval(fn.Defaults[idx], env)
+		if err, ok := val.(*object.Error); ok {
+			return nil, err
+		}
+		env.Set(param.Value, val)
 	}
 
-	return env
+	if fn.Rest != nil {
+		rest := make([]object.Object, 0)
+		if len(args) > len(fn.Parameters) {
+			rest = append(rest, args[len(fn.Parameters):]...)
+		}
+		env.Set(fn.Rest.Value, &object.Array{Elements: rest})
+	}
+
+	return env, nil
+}
+
+func checkArity(fn *object.Function, got int) *object.Error {
+	required := len(fn.Parameters)
+	if fn.Defaults != nil {
+		for required > 0 && fn.Defaults[required-1] != nil {
+			required--
+		}
+	}
+
+	max := len(fn.Parameters)
+	if got >= required && (got <= max || fn.Rest != nil) {
+		return nil
+	}
+
+	var expected string
+	switch {
+	case fn.Rest != nil:
+		expected = fmt.Sprintf("at least %d", required)
+	case required == max:
+		expected = fmt.Sprintf("%d", required)
+	default:
+		expected = fmt.Sprintf("%d to %d", required, max)
+	}
+
+	return throw(diag.CodeArity, "wrong number of arguments to %s: expected %s, got %d", functionName(fn), expected, got)
+}
+
+func functionName(fn *object.Function) string {
+	if fn.Name == "" {
+		return "anonymous function"
+	}
+	return fn.Name
 }
 
 func unwrapReturnValue(obj object.Object) object.Object {
-	if returnValue, ok := obj.(*object.ReturnValue); ok {
-		return returnValue
+	switch obj := obj.(type) {
+	case *object.ReturnValue:
+		return obj.Value
+	case nil:
+		// the body is empty or ends with a statement like `let`.
+		return Null
+	default:
+		return obj
 	}
-	return obj
 }
 
-func evalExpressions(exps []ast.Expression, env *object.Environment) []object.Object {
+func (e *Evaluator) evalExpressions(exps []ast.Expression, env *object.Environment) []object.Object {
 	result := make([]object.Object, 0)
 
 	for _, exp := range exps {
-		evaluated := Eval(exp, env)
+		evaluated := e.eval(exp, env)
 		if isError(evaluated) {
 			return []object.Object{evaluated}
 		}
@@ -160,34 +639,41 @@ func evalIdentifier(node *ast.Identifier, env *object.Environment) object.Object
 		return builtin
 	}
 
-	return throw("undefined identifier: %s", node.Value)
+	return throw(diag.CodeUndefinedIdentifier, "undefined identifier: %s", node.Value)
 }
 
-func evalProgram(program *ast.Program, env *object.Environment) object.Object {
+func (e *Evaluator) evalProgram(program *ast.Program, env *object.Environment) object.Object {
 	var result object.Object
 
 	for _, statement := range program.Statements {
-		result = Eval(statement, env)
+		result = e.eval(statement, env)
 
 		switch result := result.(type) {
 		case *object.ReturnValue:
 			return result.Value
 		case *object.Error:
 			return result
+		case *object.Break, *object.Continue:
+			return escapedBranch(result)
 		}
 	}
 
 	return result
 }
 
-func evalBlockStatement(block *ast.BlockStatement, env *object.Environment) object.Object {
+func (e *Evaluator) evalBlockStatement(block *ast.BlockStatement, env *object.Environment) object.Object {
+	if err := e.step(); err != nil {
+		return err
+	}
+
 	var result object.Object
 
 	for _, statement := range block.Statements {
-		result = Eval(statement, env)
+		result = e.eval(statement, env)
 
 		if result != nil {
-			if result.Type() == object.TypeReturnValue || result.Type() == object.TypeError {
+			switch result.Type() {
+			case object.TypeReturnValue, object.TypeError, object.TypeBreak, object.TypeContinue:
 				return result
 			}
 		}
@@ -195,16 +681,16 @@ func evalBlockStatement(block *ast.BlockStatement, env *object.Environment) obje
 	return result
 }
 
-func evalIfExpression(ie *ast.IfExpression, env *object.Environment) object.Object {
-	condition := Eval(ie.Condition, env)
+func (e *Evaluator) evalIfExpression(ie *ast.IfExpression, env *object.Environment) object.Object {
+	condition := e.eval(ie.Condition, env)
 	if isError(condition) {
 		return condition
 	}
 
 	if isTruthy(condition) {
-		return Eval(ie.Consequence, env)
+		return e.eval(ie.Consequence, env)
 	} else if ie.Alternative != nil {
-		return Eval(ie.Alternative, env)
+		return e.eval(ie.Alternative, env)
 	} else {
 		return Null
 	}
@@ -223,67 +709,178 @@ func isTruthy(obj object.Object) bool {
 	}
 }
 
-func evalPrefixExpression(operator string, right object.Object) object.Object {
+// evalLogicalExpression evaluates "&&" and "||", the right operand is only
+// evaluated if the left one doesn't decide the result. The deciding operand
+// is returned as is, so `name || "default"` works for any type.
+func (e *Evaluator) evalLogicalExpression(node *ast.InfixExpression, env *object.Environment) object.Object {
+	left := e.eval(node.Left, env)
+	if isError(left) {
+		return left
+	}
+
+	if isTruthy(left) == (node.Operator == "||") {
+		return left
+	}
+
+	return e.eval(node.Right, env)
+}
+
+func (e *Evaluator) evalPrefixExpression(operator string, right object.Object) object.Object {
 	switch operator {
 	case "!":
 		return evalBangOperatorExpression(right)
 	case "-":
-		return evalMinusOperatorExpression(right)
+		return e.evalMinusOperatorExpression(right)
+	case "~":
+		return evalTildeOperatorExpression(right)
 	default:
-		return throw("unknown operation: %s%s", operator, right.Type())
+		return throw(diag.CodeUnknownOperation, "unknown operation: %s%s", operator, right.Type())
 	}
 }
 
-func evalInfixExpression(operator string, left, right object.Object) object.Object {
+func (e *Evaluator) evalInfixExpression(operator string, left, right object.Object) object.Object {
 	switch {
 	case left.Type() == object.TypeInteger && right.Type() == object.TypeInteger:
-		return evalIntegerInfixExpression(operator, left, right)
+		return e.evalIntegerInfixExpression(operator, left, right)
+	case isInteger(left) && isInteger(right):
+		return e.evalBigIntInfixExpression(operator, left, right)
+	case isNumber(left) && isNumber(right):
+		return evalFloatInfixExpression(operator, left, right)
+	case left.Type() == object.TypeString && right.Type() == object.TypeString:
+		return evalStringInfixExpression(operator, left, right)
 	case operator == "==":
 		return nativeBoolToBooleanObject(left == right)
 	case operator == "!=":
 		return nativeBoolToBooleanObject(left != right)
 	case left.Type() != right.Type():
-		return throw("type mismatch: %s %s %s", left.Type(), operator, right.Type())
-	case left.Type() == object.TypeString && right.Type() == object.TypeString:
-		return evalStringInfixExpression(operator, left, right)
+		return throw(diag.CodeTypeMismatch, "type mismatch: %s %s %s", left.Type(), operator, right.Type())
 	default:
-		return throw("unknown operation: %s %s %s", left.Type(), operator, right.Type())
+		return throw(diag.CodeUnknownOperation, "unknown operation: %s %s %s", left.Type(), operator, right.Type())
 	}
 }
 
-func evalStringInfixExpression(operator string, left, right object.Object) object.Object {
-	if operator != "+" {
-		return throw("unknown operation: %s %s %s", left.Type(), operator, right.Type())
+// evalTemplateLiteral renders a template, the values of its expressions are
+// inserted as they're printed.
+func (e *Evaluator) evalTemplateLiteral(node *ast.TemplateLiteral, env *object.Environment) object.Object {
+	var out strings.Builder
+
+	for idx, part := range node.Parts {
+		out.WriteString(part)
+		if idx >= len(node.Expressions) {
+			continue
+		}
+
+		value := e.eval(node.Expressions[idx], env)
+		if isError(value) {
+			return value
+		}
+		out.WriteString(value.Inspect())
 	}
 
+	return &object.String{Value: out.String()}
+}
+
+func evalStringInfixExpression(operator string, left, right object.Object) object.Object {
 	leftVal := left.(*object.String).Value
 	rightVal := right.(*object.String).Value
-	return &object.String{Value: leftVal + rightVal}
+
+	switch operator {
+	case "+":
+		return &object.String{Value: leftVal + rightVal}
+	case "==":
+		return nativeBoolToBooleanObject(leftVal == rightVal)
+	case "!=":
+		return nativeBoolToBooleanObject(leftVal != rightVal)
+	case "<":
+		return nativeBoolToBooleanObject(leftVal < rightVal)
+	case ">":
+		return nativeBoolToBooleanObject(leftVal > rightVal)
+	case "<=":
+		return nativeBoolToBooleanObject(leftVal <= rightVal)
+	case ">=":
+		return nativeBoolToBooleanObject(leftVal >= rightVal)
+	default:
+		return throw(diag.CodeUnknownOperation, "unknown operation: %s %s %s", left.Type(), operator, right.Type())
+	}
 }
 
-func evalIntegerInfixExpression(operator string, left, right object.Object) object.Object {
+func (e *Evaluator) evalIntegerInfixExpression(operator string, left, right object.Object) object.Object {
 	leftVal := left.(*object.Integer).Value
 	rightVal := right.(*object.Integer).Value
 
 	switch operator {
-	case "+":
-		return &object.Integer{Value: leftVal + rightVal}
-	case "-":
-		return &object.Integer{Value: leftVal - rightVal}
-	case "*":
-		return &object.Integer{Value: leftVal * rightVal}
-	case "/":
-		return &object.Integer{Value: leftVal / rightVal}
+	case "+", "-", "*", "/", "%":
+		return e.evalIntegerArithmetic(operator, leftVal, rightVal)
+	case "&", "|", "^", "<<", ">>":
+		return e.evalIntegerBitwise(operator, leftVal, rightVal)
 	case "<":
 		return nativeBoolToBooleanObject(leftVal < rightVal)
 	case ">":
 		return nativeBoolToBooleanObject(leftVal > rightVal)
+	case "<=":
+		return nativeBoolToBooleanObject(leftVal <= rightVal)
+	case ">=":
+		return nativeBoolToBooleanObject(leftVal >= rightVal)
 	case "==":
 		return nativeBoolToBooleanObject(leftVal == rightVal)
 	case "!=":
 		return nativeBoolToBooleanObject(leftVal != rightVal)
 	default:
-		return throw("unknown operation: %s %s %s", left.Type(), operator, right.Type())
+		return throw(diag.CodeUnknownOperation, "unknown operation: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
+// evalBigIntInfixExpression evaluates operators on integers of which at least
+// one is a BigInt.
+func (e *Evaluator) evalBigIntInfixExpression(operator string, left, right object.Object) object.Object {
+	leftVal := toBigInt(left)
+	rightVal := toBigInt(right)
+
+	switch operator {
+	case "+", "-", "*", "/", "%":
+		return e.evalBigIntArithmetic(operator, leftVal, rightVal)
+	case "&", "|", "^", "<<", ">>":
+		return e.evalBigIntBitwise(operator, leftVal, rightVal)
+	case "<":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) < 0)
+	case ">":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) > 0)
+	case "<=":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) <= 0)
+	case ">=":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) >= 0)
+	case "==":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) == 0)
+	case "!=":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) != 0)
+	default:
+		return throw(diag.CodeUnknownOperation, "unknown operation: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
+// evalFloatInfixExpression evaluates operators on two floats or a float and
+// an integer, the integer is promoted to a float.
+func evalFloatInfixExpression(operator string, left, right object.Object) object.Object {
+	leftVal := toFloat(left)
+	rightVal := toFloat(right)
+
+	switch operator {
+	case "+", "-", "*", "/", "%":
+		return evalFloatArithmetic(operator, leftVal, rightVal)
+	case "<":
+		return nativeBoolToBooleanObject(leftVal < rightVal)
+	case ">":
+		return nativeBoolToBooleanObject(leftVal > rightVal)
+	case "<=":
+		return nativeBoolToBooleanObject(leftVal <= rightVal)
+	case ">=":
+		return nativeBoolToBooleanObject(leftVal >= rightVal)
+	case "==":
+		return nativeBoolToBooleanObject(leftVal == rightVal)
+	case "!=":
+		return nativeBoolToBooleanObject(leftVal != rightVal)
+	default:
+		return throw(diag.CodeUnknownOperation, "unknown operation: %s %s %s", left.Type(), operator, right.Type())
 	}
 }
 
@@ -300,16 +897,27 @@ func evalBangOperatorExpression(right object.Object) object.Object {
 	}
 }
 
-func evalMinusOperatorExpression(right object.Object) object.Object {
-	if right.Type() != object.TypeInteger {
-		return throw("unknown operation: -%s", right.Type())
+func (e *Evaluator) evalMinusOperatorExpression(right object.Object) object.Object {
+	switch right := right.(type) {
+	case *object.Integer:
+		if right.Value != math.MinInt64 {
+			return &object.Integer{Value: -right.Value}
+		}
+		if e.checked {
+			return throw(diag.CodeIntegerOverflow, "integer overflow: -%d", right.Value)
+		}
+		return object.NewInteger(new(big.Int).Neg(big.NewInt(right.Value)))
+	case *object.BigInt:
+		return object.NewInteger(new(big.Int).Neg(right.Value))
+	case *object.Float:
+		return &object.Float{Value: -right.Value}
+	default:
+		return throw(diag.CodeUnknownOperation, "unknown operation: -%s", right.Type())
 	}
-	value := right.(*object.Integer).Value
-	return &object.Integer{Value: -value}
 }
 
-func throw(format string, args ...interface{}) *object.Error {
-	return &object.Error{Message: fmt.Sprintf(format, args...)}
+func throw(code diag.Code, format string, args ...interface{}) *object.Error {
+	return &object.Error{Code: code, Message: fmt.Sprintf(format, args...)}
 }
 
 func isError(obj object.Object) bool {
@@ -19,8 +19,13 @@
 package eval_test
 
 import (
+	"context"
+	"math"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/suenchunyu/snow-lang/internal/diag"
 	"github.com/suenchunyu/snow-lang/internal/eval"
 	"github.com/suenchunyu/snow-lang/internal/lexer"
 	"github.com/suenchunyu/snow-lang/internal/object"
@@ -37,6 +42,9 @@ func TestBuiltinFunctions(t *testing.T) {
 		{`len("hello world")`, 11},
 		{`len(1)`, "argument type to `len` not supported"},
 		{`len("one", "two")`, "wrong number of arguments. got 2, want 1"},
+		{`len([1, 2, 3])`, 3},
+		{`len([])`, 0},
+		{`len({"a": 1, "b": 2})`, 2},
 	}
 
 	for _, tt := range tests {
@@ -59,6 +67,221 @@ func TestBuiltinFunctions(t *testing.T) {
 	}
 }
 
+func TestArrayLiterals(t *testing.T) {
+	input := "[1, 2 * 2, 3 + 3]"
+
+	evaluated := testEval(input)
+	result, ok := evaluated.(*object.Array)
+	if !ok {
+		t.Fatalf("object is not *object.Array. got = %T (%+v)", evaluated, evaluated)
+	}
+
+	if len(result.Elements) != 3 {
+		t.Fatalf("array has wrong num of elements. got = %d", len(result.Elements))
+	}
+
+	testIntegerObject(t, result.Elements[0], 1)
+	testIntegerObject(t, result.Elements[1], 4)
+	testIntegerObject(t, result.Elements[2], 6)
+}
+
+func TestArrayInspect(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"[]", "[]"},
+		{`[1, "2", 3 + 4, 5]`, `[1, "2", 7, 5]`},
+		{`[[1, true], ["a"]]`, `[[1, true], ["a"]]`},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("wrong inspect for %q, expected = %q, got = %q", tt.input, tt.expected, evaluated.Inspect())
+		}
+	}
+}
+
+func TestArrayIndexExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"[1, 2, 3][0]", 1},
+		{"[1, 2, 3][1]", 2},
+		{"[1, 2, 3][2]", 3},
+		{"let i = 0; [1][i];", 1},
+		{"[1, 2, 3][1 + 1];", 3},
+		{"let myArray = [1, 2, 3]; myArray[2];", 3},
+		{"let myArray = [1, 2, 3]; myArray[0] + myArray[1] + myArray[2];", 6},
+		{"let myArray = [1, 2, 3]; let i = myArray[0]; myArray[i]", 2},
+		{"[1, 2, 3][3]", "index out of range: 3 (length 3)"},
+		{"[1, 2, 3][-1]", "index out of range: -1 (length 3)"},
+		{`[1, 2, 3]["1"]`, "array index must be Integer, got String"},
+		{"1[0]", "index operator not supported: Integer"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			err, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("object is not Error, got = %T (%+v)", evaluated, evaluated)
+				continue
+			}
+
+			if err.Message != expected {
+				t.Errorf("wrong error message, expected = %q, got = %q", expected, err.Message)
+			}
+		}
+	}
+}
+
+func TestHashLiterals(t *testing.T) {
+	input := `let two = "two";
+{
+    "one": 10 - 9,
+    two: 1 + 1,
+    "thr" + "ee": 6 / 2,
+    4: 4,
+    true: 5,
+    false: 6
+}`
+
+	evaluated := testEval(input)
+	result, ok := evaluated.(*object.Hash)
+	if !ok {
+		t.Fatalf("eval didn't return *object.Hash. got = %T (%+v)", evaluated, evaluated)
+	}
+
+	expected := []struct {
+		key   object.Hashable
+		value int64
+	}{
+		{&object.String{Value: "one"}, 1},
+		{&object.String{Value: "two"}, 2},
+		{&object.String{Value: "three"}, 3},
+		{&object.Integer{Value: 4}, 4},
+		{eval.True, 5},
+		{eval.False, 6},
+	}
+
+	if result.Len() != len(expected) {
+		t.Fatalf("hash has wrong num of pairs. got = %d", result.Len())
+	}
+
+	for idx, pair := range result.Pairs() {
+		if pair.Key.(object.Hashable).HashKey() != expected[idx].key.HashKey() {
+			t.Errorf("pairs[%d] - wrong key, expected = %s, got = %s", idx, expected[idx].key.Inspect(), pair.Key.Inspect())
+		}
+
+		value, ok := result.Get(expected[idx].key)
+		if !ok {
+			t.Errorf("no pair for given key in pairs")
+			continue
+		}
+		testIntegerObject(t, value, expected[idx].value)
+	}
+}
+
+func TestHashKeys(t *testing.T) {
+	hello1 := &object.String{Value: "Hello World"}
+	hello2 := &object.String{Value: "Hello World"}
+	diff := &object.String{Value: "My name is johnny"}
+
+	if hello1.HashKey() != hello2.HashKey() {
+		t.Errorf("strings with same content have different hash keys")
+	}
+
+	if hello1.HashKey() == diff.HashKey() {
+		t.Errorf("strings with different content have same hash keys")
+	}
+
+	one := &object.Integer{Value: 1}
+	if one.HashKey() == eval.True.HashKey() {
+		t.Errorf("objects of different types have same hash keys")
+	}
+
+	if (&object.String{Value: "1"}).HashKey() == one.HashKey() {
+		t.Errorf("objects of different types have same hash keys")
+	}
+}
+
+func TestHashIndexExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`{"foo": 5}["foo"]`, 5},
+		{`{"foo": 5}["bar"]`, nil},
+		{`let key = "foo"; {"foo": 5}[key]`, 5},
+		{`{}["foo"]`, nil},
+		{`{5: 5}[5]`, 5},
+		{`{true: 5}[true]`, 5},
+		{`{false: 5}[false]`, 5},
+		{`{"foo": 5}[fn(x) { x }]`, "unusable as hash key: Function"},
+		{`{[1]: 5}`, "unusable as hash key: Array"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			err, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("object is not Error, got = %T (%+v)", evaluated, evaluated)
+				continue
+			}
+
+			if err.Message != expected {
+				t.Errorf("wrong error message, expected = %q, got = %q", expected, err.Message)
+			}
+		default:
+			if evaluated != eval.Null {
+				t.Errorf("object is not Null, got = %T (%+v)", evaluated, evaluated)
+			}
+		}
+	}
+}
+
+func TestHashInspect(t *testing.T) {
+	input := `{"name": "Jack", "Age": 24, "Gender": "Male", "name": "Rose", 1: [true]}`
+	expected := `{"name": "Rose", "Age": 24, "Gender": "Male", 1: [true]}`
+
+	evaluated := testEval(input)
+	if evaluated.Inspect() != expected {
+		t.Errorf("wrong inspect, expected = %q, got = %q", expected, evaluated.Inspect())
+	}
+}
+
+func TestSelfReferenceInspect(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let a = [1]; a[0] = a; a", "[[...]]"},
+		{"let a = [1, 2]; a[1] = [a]; a", "[1, [[...]]]"},
+		{`let h = {"a": 1}; h["self"] = h; h`, `{"a": 1, "self": {...}}`},
+		{`let h = {}; let a = [h]; h["a"] = a; a`, `[{"a": [...]}]`},
+		{"let x = [1]; [x, x]", "[[1], [1]]"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("wrong inspect for %q, expected = %q, got = %q", tt.input, tt.expected, evaluated.Inspect())
+		}
+	}
+}
+
 func TestStringConcatenation(t *testing.T) {
 	input := `"Hello" + " " + "World!"`
 
@@ -87,6 +310,67 @@ func TestStringLiteral(t *testing.T) {
 	}
 }
 
+func TestStringEscapes(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`"say \"hi\""`, `say "hi"`},
+		{`"a\tb"`, "a\tb"},
+		{`"caf\u{E9}"`, "café"},
+		{"`C:\\path\\n`", `C:\path\n`},
+		{"`one\ntwo` + \"\\n\"", "one\ntwo\n"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		str, ok := evaluated.(*object.String)
+		if !ok {
+			t.Errorf("object is not String for %s, got = %T (%+v)", tt.input, evaluated, evaluated)
+			continue
+		}
+
+		if str.Value != tt.expected {
+			t.Errorf("%s wrong, expected = %q, got = %q", tt.input, tt.expected, str.Value)
+		}
+	}
+
+	testIntegerObject(t, testEval(`len("a\nb")`), 3)
+}
+
+func TestTemplateLiterals(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let name = "snow"; "Hello ${name}!"`, "Hello snow!"},
+		{`let count = 2; "you have ${count + 1} items"`, "you have 3 items"},
+		{`let user = {"name": "Ann"}; "${user.name} ${user["name"]}"`, "Ann Ann"},
+		{`"${1.5} ${true} ${[1, "a"]} ${{"k": 2}}"`, `1.5 true [1, "a"] {"k": 2}`},
+		{`"${if (false) { 1 }}"`, "null"},
+		{`let n = 3; "${"${n}" + "${n}"}"`, "33"},
+		{`fn greet(who) { "hi ${who}" } greet("there")`, "hi there"},
+		{`"costs \${price}"`, "costs ${price}"},
+		{`"${nope}"`, "undefined identifier: nope"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		got := evaluated.Inspect()
+		if err, ok := evaluated.(*object.Error); ok {
+			got = err.Message
+		} else if evaluated.Type() != object.TypeString {
+			t.Errorf("%s: object is not String, got = %T (%+v)", tt.input, evaluated, evaluated)
+			continue
+		}
+
+		if got != tt.expected {
+			t.Errorf("%s wrong, expected = %q, got = %q", tt.input, tt.expected, got)
+		}
+	}
+}
+
 func TestFunctionObject(t *testing.T) {
 	input := "fn(x) { x + 2; };"
 
@@ -144,6 +428,61 @@ func TestLetStatements(t *testing.T) {
 	}
 }
 
+func TestAssignExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"let a = 1; a = 2; a;", 2},
+		{"let a = 1; a = 2;", 2},
+		{"let a = 1; let b = 1; a = b = 5; a + b;", 10},
+		{"let a = 10; a += 5; a;", 15},
+		{"let a = 10; a -= 5; a;", 5},
+		{"let a = 10; a *= 5; a;", 50},
+		{"let a = 10; a /= 5; a;", 2},
+		{`let s = "Hello"; s += ", Snow"; s;`, "Hello, Snow"},
+		{"let a = 1; let f = fn() { a = a + 1; }; f(); f(); a;", 3},
+		{"let a = 1; let f = fn() { let a = 5; a = 6; }; f(); a;", 1},
+		{"let arr = [1, 2, 3]; arr[1] = 5; arr[1];", 5},
+		{"let arr = [1, 2, 3]; arr[2] += 4; arr[2];", 7},
+		{`let map = {"age": 24}; map["age"] = 23; map["age"];`, 23},
+		{`let map = {}; map["newest"] = 1; map["newest"] += 1; map["newest"];`, 2},
+		{"b = 1;", "assignment to undeclared identifier: b"},
+		{"b += 1;", "undefined identifier: b"},
+		{"let arr = [1]; arr[1] = 2;", "index out of range: 1 (length 1)"},
+		{`let arr = [1]; arr["a"] = 2;`, "array index must be Integer, got String"},
+		{`let map = {}; map[[1]] = 2;`, "unusable as hash key: Array"},
+		{`let s = "a"; s[0] = "b";`, "index assignment not supported: String"},
+		{"let a = 1; a += true;", "type mismatch: Integer + Boolean"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			if str, ok := evaluated.(*object.String); ok {
+				if str.Value != expected {
+					t.Errorf("String has wrong value. expected = %q, got = %q", expected, str.Value)
+				}
+				continue
+			}
+
+			err, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("object is not Error, got = %T (%+v)", evaluated, evaluated)
+				continue
+			}
+
+			if err.Message != expected {
+				t.Errorf("wrong error message, expected = %q, got = %q", expected, err.Message)
+			}
+		}
+	}
+}
+
 func TestEvalIntegerExpression(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -196,6 +535,21 @@ func TestEvalBooleanExpression(t *testing.T) {
 		{"(1 < 2) == false", false},
 		{"(1 > 2) == true", false},
 		{"(1 > 2) == false", true},
+		{"1 <= 2", true},
+		{"2 <= 2", true},
+		{"3 <= 2", false},
+		{"1 >= 2", false},
+		{"2 >= 2", true},
+		{"1 < 2 && 2 < 3", true},
+		{"1 < 2 && 2 > 3", false},
+		{"1 > 2 || 2 < 3", true},
+		{"1 > 2 || 2 > 3", false},
+		{`"a" == "a"`, true},
+		{`"a" != "a"`, false},
+		{`"a" < "b"`, true},
+		{`"b" <= "a"`, false},
+		{`"abc" > "abd"`, false},
+		{`"snow" >= "snow"`, true},
 	}
 
 	for _, tt := range tests {
@@ -321,6 +675,68 @@ if (10 > 1) {
 	}
 }
 
+func TestErrorCodes(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected diag.Code
+	}{
+		{"foobar", diag.CodeUndefinedIdentifier},
+		{"5 + true;", diag.CodeTypeMismatch},
+		{"-true", diag.CodeUnknownOperation},
+		{"let a = 5; a(1);", diag.CodeNotCallable},
+		{`len(1)`, diag.CodeArgument},
+		{"for x in true { x }", diag.CodeNotIterable},
+		{"continue;", diag.CodeBranchOutsideLoop},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned, got = %T (%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if errObj.Code != tt.expected {
+			t.Errorf("wrong error code for %q, expected = %q, got = %q", tt.input, tt.expected, errObj.Code)
+		}
+
+		if errObj.Diagnostic().Code != tt.expected {
+			t.Errorf("wrong diagnostic code for %q, expected = %q, got = %q", tt.input, tt.expected, errObj.Diagnostic().Code)
+		}
+	}
+}
+
+func TestErrorPositions(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedLine   int
+		expectedColumn int
+	}{
+		{"5 + true;", 1, 3},
+		{"let a = 1;\nlet b = -true;", 2, 9},
+		{"let f = fn(x) {\n  x / \"a\"\n};\nf(1);", 2, 5},
+		{"let a = 1;\n  foobar;", 2, 3},
+		{"len(1, 2)", 1, 1},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned, got = %T (%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if errObj.Pos.Line != tt.expectedLine || errObj.Pos.Column != tt.expectedColumn {
+			t.Errorf("wrong error position for %q, expected = %d:%d, got = %s",
+				tt.input, tt.expectedLine, tt.expectedColumn, errObj.Pos)
+		}
+	}
+}
+
 func TestBangOperator(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -380,3 +796,763 @@ func testEval(input string) object.Object {
 
 	return eval.Eval(program, env)
 }
+
+func TestLoops(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"let sum = 0; for (let i = 0; i < 50; i += 1) { sum += i; }; sum;", 1225},
+		{"let i = 0; for (; i < 5;) { i += 1 }; i;", 5},
+		{"let i = 0; while (i < 10) { i += 1; }; i;", 10},
+		{"let i = 0; while (true) { i += 1; if (i == 7) { break; } }; i;", 7},
+		{"let n = 0; for (let i = 0; i < 10; i += 1) { if (i < 5) { continue; } n += 1; }; n;", 5},
+		{"let n = 0; for (let i = 0; i < 3; i += 1) { for (let j = 0; j < 3; j += 1) { if (j == 1) { break; } n += 1; } }; n;", 3},
+		{"let sum = 0; for x in [1, 2, 3] { sum += x; }; sum;", 6},
+		{`let s = ""; for k in {"a": 1, "b": 2, "c": 3} { s += k; }; s;`, "abc"},
+		{`let s = ""; for (c in "snow") { s = c + s; }; s;`, "wons"},
+		{"let f = fn() { for x in [1, 2, 3] { if (x == 2) { return x * 10; } } }; f();", 20},
+		{"let f = fn() { while (true) { return 1; } }; f();", 1},
+		{"for x in 5 { x }", "cannot iterate over Integer"},
+		{"break;", "break outside loop"},
+		{"let f = fn() { continue; }; for x in [1] { f(); }", "continue outside loop"},
+		{"for (let i = 0; i < 3; i += 1) { foobar }", "undefined identifier: foobar"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			if str, ok := evaluated.(*object.String); ok {
+				if str.Value != expected {
+					t.Errorf("String has wrong value. expected = %q, got = %q", expected, str.Value)
+				}
+				continue
+			}
+
+			err, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("object is not Error, got = %T (%+v)", evaluated, evaluated)
+				continue
+			}
+
+			if err.Message != expected {
+				t.Errorf("wrong error message, expected = %q, got = %q", expected, err.Message)
+			}
+		}
+	}
+}
+
+func TestLogicalOperators(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"1 && 2", 2},
+		{"false && 2", false},
+		{"1 || 2", 1},
+		{"false || 2", 2},
+		{`let name = ""; name || "snow"`, ""},
+		{"let a = 0; false && (a = 1); a;", 0},
+		{"let a = 0; true || (a = 1); a;", 0},
+		{"let a = 0; true && (a = 1); a;", 1},
+		{"false && foobar", false},
+		{"true && foobar", "undefined identifier: foobar"},
+		{"7 % 3", 1},
+		{"-7 % 3", -1},
+		{"let a = 10; a %= 4; a;", 2},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		case string:
+			if str, ok := evaluated.(*object.String); ok {
+				if str.Value != expected {
+					t.Errorf("String has wrong value. expected = %q, got = %q", expected, str.Value)
+				}
+				continue
+			}
+
+			err, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("object is not Error, got = %T (%+v)", evaluated, evaluated)
+				continue
+			}
+
+			if err.Message != expected {
+				t.Errorf("wrong error message, expected = %q, got = %q", expected, err.Message)
+			}
+		}
+	}
+}
+
+func TestFunctionStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"fn add(x, y) { x + y } add(1, 2);", 3},
+		{"fn fact(n) { if n < 2 { 1 } else { n * fact(n - 1) } } fact(5);", 120},
+		{"let f = fn() { fn inner(x) { x * 2 } inner(21) }; f();", 42},
+		{"fn a() { 1 } fn a() { 2 } a();", 2},
+	}
+
+	for _, tt := range tests {
+		testIntegerObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func TestFunctionName(t *testing.T) {
+	evaluated := testEval("fn double(x) { x * 2 }; double;")
+
+	fn, ok := evaluated.(*object.Function)
+	if !ok {
+		t.Fatalf("object is not *object.Function. got = %T (%+v)", evaluated, evaluated)
+	}
+
+	if fn.Name != "double" {
+		t.Errorf("function name is not %q. got = %q", "double", fn.Name)
+	}
+
+	expected := "fn double(x) (x * 2)"
+	if fn.Inspect() != expected {
+		t.Errorf("expected = %q, got = %q", expected, fn.Inspect())
+	}
+}
+
+func TestFunctionCallSemantics(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		// return values are unwrapped at the call site
+		{"fn f() { return 1 } f() + f();", 2},
+		{"let f = fn(x) { if (x > 0) { return x } 0 }; f(2) * f(3);", 6},
+		{"fn fib(n) { if n < 2 { return n } else { return fib(n - 1) + fib(n - 2) } } fib(10);", 55},
+		{"let f = fn() { return 1; }; let g = fn() { return f() + 1; }; g() + 1;", 3},
+		// statements in tail position yield null
+		{"fn f() { let a = 1; } f();", nil},
+		{"fn f() {} f();", nil},
+		// arity
+		{"let add = fn(x, y) { x + y }; add(1);", "wrong number of arguments to add: expected 2, got 1"},
+		{"fn add(x, y) { x + y } add(1, 2, 3);", "wrong number of arguments to add: expected 2, got 3"},
+		{"fn(x) { x }();", "wrong number of arguments to anonymous function: expected 1, got 0"},
+		{"fn f(x, y = 1) { x + y } f();", "wrong number of arguments to f: expected 1 to 2, got 0"},
+		{"fn f(x, ...rest) { x } f();", "wrong number of arguments to f: expected at least 1, got 0"},
+		// defaults
+		{"fn f(x, y = 10) { x + y } f(1);", 11},
+		{"fn f(x, y = 10) { x + y } f(1, 2);", 3},
+		{"fn f(x = 1, y = x * 2) { x + y } f();", 3},
+		{"fn f(x = 1, y = x * 2) { x + y } f(5);", 15},
+		{"let a = 100; fn f(x = a) { x } a = 7; f();", 7},
+		{"fn f(x = foobar) { x } f();", "undefined identifier: foobar"},
+		// variadic parameters
+		{"fn f(...rest) { len(rest) } f();", 0},
+		{"fn f(...rest) { len(rest) } f(1, 2, 3);", 3},
+		{"fn f(x, ...rest) { rest[1] } f(1, 2, 3);", 3},
+		{"fn sum(...xs) { let s = 0; for x in xs { s += x }; s } sum(1, 2, 3, 4);", 10},
+		{"fn f(x, y = 2, ...rest) { x + y + len(rest) } f(1);", 3},
+		{"fn f(x, y = 2, ...rest) { x + y + len(rest) } f(1, 5, 0, 0);", 8},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case nil:
+			if evaluated != eval.Null {
+				t.Errorf("object is not Null for %q, got = %T (%+v)", tt.input, evaluated, evaluated)
+			}
+		case string:
+			err, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("object is not Error for %q, got = %T (%+v)", tt.input, evaluated, evaluated)
+				continue
+			}
+
+			if err.Message != expected {
+				t.Errorf("wrong error message, expected = %q, got = %q", expected, err.Message)
+			}
+		}
+	}
+}
+
+func TestArityErrorCode(t *testing.T) {
+	evaluated := testEval("fn f(x) { x }\nf(1, 2);")
+
+	err, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("object is not Error, got = %T (%+v)", evaluated, evaluated)
+	}
+
+	if err.Code != diag.CodeArity {
+		t.Errorf("wrong error code, expected = %q, got = %q", diag.CodeArity, err.Code)
+	}
+
+	if err.Pos.String() != "2:1" {
+		t.Errorf("wrong error position, expected = %q, got = %q", "2:1", err.Pos)
+	}
+}
+
+func TestDivisionByZero(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"1 / 0", "division by zero"},
+		{"let a = 0; 10 % a", "modulo by zero"},
+		{"let a = 5; a /= 0;", "division by zero"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		err, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("object is not Error for %q, got = %T (%+v)", tt.input, evaluated, evaluated)
+			continue
+		}
+
+		if err.Code != diag.CodeDivisionByZero || err.Message != tt.expected {
+			t.Errorf("wrong error for %q, expected = %q, got = %s %q", tt.input, tt.expected, err.Code, err.Message)
+		}
+	}
+}
+
+func TestCheckedArithmetic(t *testing.T) {
+	const max = "9223372036854775807"
+
+	tests := []struct {
+		input     string
+		unchecked string
+		overflow  bool
+	}{
+		{max + " + 1", "9223372036854775808", true},
+		{"-" + max + " - 2", "-9223372036854775809", true},
+		{max + " * 2", "18446744073709551614", true},
+		{"(-" + max + " - 1) / -1", "9223372036854775808", true},
+		{"-(-" + max + " - 1)", "9223372036854775808", true},
+		{"(-" + max + " - 1) * -1", "9223372036854775808", true},
+		{max + " - 1 + 1", max, false},
+		{"-" + max + " - 1", "-9223372036854775808", false},
+		{"3037000499 * 3037000499", "9223372030926249001", false},
+		{"-3 * 4", "-12", false},
+	}
+
+	for _, tt := range tests {
+		program := parser.New(lexer.New(tt.input)).Parse()
+
+		if got := eval.Eval(program, object.NewEnv()).Inspect(); got != tt.unchecked {
+			t.Errorf("wrong unchecked result for %q, expected = %s, got = %s", tt.input, tt.unchecked, got)
+		}
+
+		evaluated := eval.New(eval.WithCheckedArithmetic()).Eval(program, object.NewEnv())
+		if !tt.overflow {
+			if got := evaluated.Inspect(); got != tt.unchecked {
+				t.Errorf("wrong checked result for %q, expected = %s, got = %s", tt.input, tt.unchecked, got)
+			}
+			continue
+		}
+
+		err, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("object is not Error for %q, got = %T (%+v)", tt.input, evaluated, evaluated)
+			continue
+		}
+
+		if err.Code != diag.CodeIntegerOverflow {
+			t.Errorf("wrong error code for %q, expected = %q, got = %q", tt.input, diag.CodeIntegerOverflow, err.Code)
+		}
+	}
+}
+
+func TestBigIntegers(t *testing.T) {
+	const big = "123456789012345678901234567890"
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{big, big},
+		{"-" + big, "-" + big},
+		{big + " + 1", "123456789012345678901234567891"},
+		{big + " - " + big, "0"},
+		{big + " / " + big, "1"},
+		{"(" + big + " * 2) / 2 == " + big, "true"},
+		{"-" + big + " % 11", "-7"},
+		{"9223372036854775808 - 1", "9223372036854775807"},
+		{"-9223372036854775808", "-9223372036854775808"},
+		{"9223372036854775807 + 1 > 9223372036854775807", "true"},
+		{"9223372036854775808 == 9223372036854775807 + 1", "true"},
+		{"9223372036854775808 != 1", "true"},
+		{"fn fact(n, acc) { if (n < 2) { acc } else { fact(n - 1, acc * n) } } fact(25, 1)", "15511210043330985984000000"},
+		{`let h = {9223372036854775807 + 1: "big", 1: "small"}; h[9223372036854775808]`, "big"},
+		{`let h = {9223372036854775808 - 1: "small"}; h[9223372036854775807]`, "small"},
+		{big + " / 0", "division by zero"},
+		{"[1][" + big + "]", "index out of range: " + big + " (length 1)"},
+		{big + " + 0.5", "1.2345678901234568e+29"},
+		{"int(1e19)", "10000000000000000000"},
+		{"float(" + big + ")", "1.2345678901234568e+29"},
+		{`int("` + big + `")`, big},
+		{big + ` + "a"`, "type mismatch: BigInt + String"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		got := evaluated.Inspect()
+		if err, ok := evaluated.(*object.Error); ok {
+			got = err.Message
+		}
+
+		if got != tt.expected {
+			t.Errorf("%s wrong, expected = %q, got = %q", tt.input, tt.expected, got)
+		}
+	}
+
+	// results which fit into an int64 are plain integers again.
+	testIntegerObject(t, testEval("9223372036854775808 - 1"), math.MaxInt64)
+}
+
+func TestInternalErrorIsRecovered(t *testing.T) {
+	env := object.NewEnv()
+	// a nil binding can't be produced by scripts, it makes the evaluator panic.
+	env.Set("broken", nil)
+
+	program := parser.New(lexer.New("-broken")).Parse()
+	evaluated := eval.Eval(program, env)
+
+	err, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("object is not Error, got = %T (%+v)", evaluated, evaluated)
+	}
+
+	if err.Code != diag.CodeInternal {
+		t.Errorf("wrong error code, expected = %q, got = %q", diag.CodeInternal, err.Code)
+	}
+
+	if !strings.HasPrefix(err.Message, "internal error: ") {
+		t.Errorf("wrong error message, got = %q", err.Message)
+	}
+}
+
+func TestErrorTrace(t *testing.T) {
+	input := `fn fibonacci(num) {
+    if num < 2 {
+        return num + nope
+    } else {
+        return fibonacci(num - 1) + fibonacci(num - 2)
+    }
+}
+let wrapper = fn() { let value = fibonacci(2); value };
+wrapper();`
+
+	l := lexer.NewFile("fib.snow", input)
+	evaluated := eval.Eval(parser.New(l).Parse(), object.NewEnv())
+
+	err, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("object is not Error, got = %T (%+v)", evaluated, evaluated)
+	}
+
+	expected := []string{
+		"at fibonacci (fib.snow:5:16)",
+		"at fibonacci (fib.snow:8:34)",
+		"at wrapper (fib.snow:9:1)",
+	}
+
+	if len(err.Trace) != len(expected) {
+		t.Fatalf("wrong trace length, expected = %d, got = %d (%v)", len(expected), len(err.Trace), err.Trace)
+	}
+
+	for idx, frame := range err.Trace {
+		if frame.String() != expected[idx] {
+			t.Errorf("trace[%d] wrong, expected = %q, got = %q", idx, expected[idx], frame.String())
+		}
+	}
+
+	if len(err.Diagnostic().Trace) != len(expected) {
+		t.Errorf("trace missing from diagnostic, got = %v", err.Diagnostic().Trace)
+	}
+}
+
+func TestErrorTraceIsUnwound(t *testing.T) {
+	e := eval.New()
+	env := object.NewEnv()
+
+	first := e.Eval(parser.New(lexer.New("fn f() { nope } f()")).Parse(), env)
+	if err, ok := first.(*object.Error); !ok || len(err.Trace) != 1 {
+		t.Fatalf("expected an error with one frame, got = %+v", first)
+	}
+
+	second := e.Eval(parser.New(lexer.New("nope")).Parse(), env)
+	if err, ok := second.(*object.Error); !ok || len(err.Trace) != 0 {
+		t.Errorf("expected an error without frames, got = %+v", second)
+	}
+}
+
+func TestStepBudget(t *testing.T) {
+	tests := []struct {
+		input    string
+		exceeded bool
+	}{
+		{"while (true) {}", true},
+		{"fn f() { f() } f()", true},
+		{"for (let i = 0; i < 1000; i += 1) {}", true},
+		{"for (let i = 0; i < 10; i += 1) {}", false},
+		{"fn f(n) { if (n > 0) { f(n - 1) } } f(10)", false},
+	}
+
+	for _, tt := range tests {
+		program := parser.New(lexer.New(tt.input)).Parse()
+		evaluated := eval.New(eval.WithStepBudget(100)).Eval(program, object.NewEnv())
+
+		err, ok := evaluated.(*object.Error)
+		if !tt.exceeded {
+			if ok {
+				t.Errorf("unexpected error for %q: %s", tt.input, err.Message)
+			}
+			continue
+		}
+
+		if !ok {
+			t.Errorf("object is not Error for %q, got = %T (%+v)", tt.input, evaluated, evaluated)
+			continue
+		}
+
+		if err.Code != diag.CodeBudgetExceeded || err.Message != "step budget exceeded (100 steps)" {
+			t.Errorf("wrong error for %q, got = %s %q", tt.input, err.Code, err.Message)
+		}
+	}
+}
+
+func TestStepBudgetIsPerEvaluation(t *testing.T) {
+	e := eval.New(eval.WithStepBudget(100))
+	program := parser.New(lexer.New("for (let i = 0; i < 40; i += 1) {}")).Parse()
+
+	for idx := 0; idx < 3; idx++ {
+		if err, ok := e.Eval(program, object.NewEnv()).(*object.Error); ok {
+			t.Fatalf("evaluation %d failed: %s", idx, err.Message)
+		}
+	}
+}
+
+func TestEvalContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	program := parser.New(lexer.New("fn spin() { while (true) {} } spin()")).Parse()
+	evaluated := eval.New().EvalContext(ctx, program, object.NewEnv())
+
+	err, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("object is not Error, got = %T (%+v)", evaluated, evaluated)
+	}
+
+	if err.Code != diag.CodeCancelled {
+		t.Errorf("wrong error code, expected = %q, got = %q", diag.CodeCancelled, err.Code)
+	}
+
+	if err.Message != "execution cancelled: context deadline exceeded" {
+		t.Errorf("wrong error message, got = %q", err.Message)
+	}
+
+	if len(err.Trace) != 1 || err.Trace[0].Function != "spin" {
+		t.Errorf("wrong trace, got = %v", err.Trace)
+	}
+}
+
+func TestMaxCallDepth(t *testing.T) {
+	input := "fn count(n) { if (n == 0) { 0 } else { 1 + count(n - 1) } }"
+
+	tests := []struct {
+		call     string
+		expected interface{}
+	}{
+		{"count(4)", 4},
+		{"count(5)", "maximum call depth exceeded (5)"},
+		{"count(-1)", "maximum call depth exceeded (5)"},
+	}
+
+	for _, tt := range tests {
+		program := parser.New(lexer.New(input + tt.call)).Parse()
+		evaluated := eval.New(eval.WithMaxCallDepth(5)).Eval(program, object.NewEnv())
+
+		if expected, ok := tt.expected.(int); ok {
+			testIntegerObject(t, evaluated, int64(expected))
+			continue
+		}
+
+		err, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("object is not Error for %q, got = %T (%+v)", tt.call, evaluated, evaluated)
+			continue
+		}
+
+		if err.Code != diag.CodeCallDepth || err.Message != tt.expected {
+			t.Errorf("wrong error for %q, expected = %q, got = %s %q", tt.call, tt.expected, err.Code, err.Message)
+		}
+
+		if len(err.Trace) != 5 || err.Trace[0].Function != "count" {
+			t.Errorf("wrong trace for %q, got = %v", tt.call, err.Trace)
+		}
+	}
+}
+
+func TestDefaultMaxCallDepth(t *testing.T) {
+	// the recursive call isn't in tail position, every call keeps a frame.
+	program := parser.New(lexer.New("fn f(x) { 1 + f(x) } f(1)")).Parse()
+
+	evaluators := map[string]*eval.Evaluator{
+		"default":  eval.New(),
+		"zero":     eval.New(eval.WithMaxCallDepth(0)),
+		"negative": eval.New(eval.WithMaxCallDepth(-1)),
+	}
+
+	for name, evaluator := range evaluators {
+		evaluated := evaluator.Eval(program, object.NewEnv())
+
+		err, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("%s: object is not Error, got = %T (%+v)", name, evaluated, evaluated)
+			continue
+		}
+
+		if err.Code != diag.CodeCallDepth {
+			t.Errorf("%s: wrong error code, expected = %q, got = %q", name, diag.CodeCallDepth, err.Code)
+		}
+
+		if len(err.Trace) != eval.DefaultMaxCallDepth {
+			t.Errorf("%s: wrong trace length, expected = %d, got = %d", name, eval.DefaultMaxCallDepth, len(err.Trace))
+		}
+	}
+}
+
+func TestTailCalls(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"fn sum(n, acc) { if (n == 0) { acc } else { sum(n - 1, acc + n) } } sum(50000, 0)", 1250025000},
+		{"fn sum(n, acc) { if (n == 0) { return acc } return sum(n - 1, acc + n) } sum(50000, 0)", 1250025000},
+		{`fn even(n) { if (n == 0) { true } else { odd(n - 1) } }
+fn odd(n) { if (n == 0) { false } else { even(n - 1) } }
+even(100001)`, false},
+		{`let items = [];
+fn fill(n) { if (n == 0) { return items } items = [n, items]; fill(n - 1) }
+fn size(list, acc) { if (len(list) == 0) { acc } else { size(list[1], acc + 1) } }
+size(fill(30000), 0)`, 30000},
+		{"fn f(n) { while (true) { return if (n == 0) { 7 } else { f(n - 1) } } } f(20000)", 7},
+		{"fn twice(x) { len(x) } twice([1, 2])", 2},
+		{"fn f(n) { if (n == 0) { nope } else { f(n - 1) } } f(3)", "undefined identifier: nope"},
+	}
+
+	for _, tt := range tests {
+		program := parser.New(lexer.New(tt.input)).Parse()
+		evaluated := eval.New(eval.WithMaxCallDepth(100)).Eval(program, object.NewEnv())
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		case string:
+			err, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("object is not Error for %q, got = %T (%+v)", tt.input, evaluated, evaluated)
+				continue
+			}
+			if err.Message != expected {
+				t.Errorf("wrong error message, expected = %q, got = %q", expected, err.Message)
+			}
+			// the frames of the tail calls replace each other.
+			if len(err.Trace) != 1 || err.Trace[0].String() != "at f (1:39)" {
+				t.Errorf("wrong trace, got = %v", err.Trace)
+			}
+		}
+	}
+}
+
+func TestFloatExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"3.14", "3.14"},
+		{"-2.5", "-2.5"},
+		{"1.5 + 1.5", "3.0"},
+		{"1 + 0.5", "1.5"},
+		{"0.5 * 4", "2.0"},
+		{"7 / 2.0", "3.5"},
+		{"7 / 2", "3"},
+		{"7.5 % 2", "1.5"},
+		{"0.1 + 0.2", "0.30000000000000004"},
+		{"1e21", "1e+21"},
+		{"1000000.0", "1000000.0"},
+		{"float(123456789)", "123456789.0"},
+		{"1234567.891", "1234567.891"},
+		{"-1e20", "-100000000000000000000.0"},
+		{"0.000001", "0.000001"},
+		{"0.0000001", "1e-07"},
+		{"-0.0", "-0.0"},
+		{`"total: ${2500000.5 * 2}"`, "total: 5000001.0"},
+		{"2.5e-3", "0.0025"},
+		{"1 < 1.5", "true"},
+		{"2.0 >= 2", "true"},
+		{"1 == 1.0", "true"},
+		{"0.5 != 0.5", "false"},
+		{`{1.5: "a"}[1.5]`, "a"},
+		{`{0.0: "zero"}[-0.0]`, "zero"},
+		{`{1: "a"}[1.0]`, "a"},
+		{`{1.0: "a"}[1]`, "a"},
+		{`len({1: 1, 1.0: 2})`, "1"},
+		{`{1: 1, 1.0: 2}[1]`, "2"},
+		{`{1 << 70: "big"}[1180591620717411303424.0]`, "big"},
+		{"1 << 70 == 1180591620717411303424.0", "true"},
+		{"1.5 / 0", "division by zero"},
+		{"1.5 % 0.0", "modulo by zero"},
+		{`1.5 + "a"`, "type mismatch: Float + String"},
+		{"!1.5", "false"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		got := evaluated.Inspect()
+		if err, ok := evaluated.(*object.Error); ok {
+			got = err.Message
+		}
+
+		if got != tt.expected {
+			t.Errorf("%s wrong, expected = %q, got = %q", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestNumberConversions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"int(3.99)", "3"},
+		{"int(-3.99)", "-3"},
+		{"int(7)", "7"},
+		{`int("42")`, "42"},
+		{"float(2)", "2.0"},
+		{"float(2.5)", "2.5"},
+		{`float("1e3")`, "1000.0"},
+		{`int(float("-inf"))`, "cannot convert -Inf to integer"},
+		{`int("4.2")`, `could not parse "4.2" as integer`},
+		{`float("x")`, `could not parse "x" as float`},
+		{"int(true)", "argument type to `int` not supported, got Boolean"},
+		{"float()", "wrong number of arguments. got 0, want 1"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		got := evaluated.Inspect()
+		if err, ok := evaluated.(*object.Error); ok {
+			got = err.Message
+		}
+
+		if got != tt.expected {
+			t.Errorf("%s wrong, expected = %q, got = %q", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestBitwiseOperators(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"0xFF & 0x0F", "15"},
+		{"0b1010 | 0b0101", "15"},
+		{"0b1100 ^ 0b1010", "6"},
+		{"~0", "-1"},
+		{"~0b1010", "-11"},
+		{"1 << 10", "1024"},
+		{"1_024 >> 3", "128"},
+		{"-16 >> 2", "-4"},
+		{"-1 >> 100", "-1"},
+		{"1 + 2 << 3", "17"},
+		{"6 & 3 == 2", "true"},
+		{"1 << 64", "18446744073709551616"},
+		{"1 << 63 >> 63", "1"},
+		{"(1 << 100) & (1 << 100 | 1)", "1267650600228229401496703205376"},
+		{"~(1 << 64)", "-18446744073709551617"},
+		{"(1 << 70) >> 70", "1"},
+		{"-(1 << 70) >> 100", "-1"},
+		{"1 << -1", "negative shift count: -1"},
+		{"1 << (1 << 64)", "shift count too large: 18446744073709551616"},
+		{"1 << 2000000", "shift count too large: 2000000"},
+		{"1.5 & 1", "unknown operation: Float & Integer"},
+		{`~"a"`, "unknown operation: ~String"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		got := evaluated.Inspect()
+		if err, ok := evaluated.(*object.Error); ok {
+			got = err.Message
+		}
+
+		if got != tt.expected {
+			t.Errorf("%s wrong, expected = %q, got = %q", tt.input, tt.expected, got)
+		}
+	}
+
+	evaluated := eval.New(eval.WithCheckedArithmetic()).Eval(parser.New(lexer.New("1 << 63")).Parse(), object.NewEnv())
+	if err, ok := evaluated.(*object.Error); !ok || err.Code != diag.CodeIntegerOverflow {
+		t.Errorf("expected an overflow error in checked mode, got = %+v", evaluated)
+	}
+}
+
+func TestHashPropertyExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`let config = {"port": 80}; config.port`, 80},
+		{`let config = {"server": {"port": 80}}; config.server.port + 1`, 81},
+		{`let config = {}; config.missing`, nil},
+		{`let m = {"f": fn(x) { x * 2 }}; m.f(21)`, 42},
+		{`let a = 1; a.b`, "property access not supported: Integer"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case nil:
+			if evaluated != eval.Null {
+				t.Errorf("object is not Null for %q, got = %T (%+v)", tt.input, evaluated, evaluated)
+			}
+		case string:
+			err, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("object is not Error for %q, got = %T (%+v)", tt.input, evaluated, evaluated)
+				continue
+			}
+
+			if err.Message != expected {
+				t.Errorf("wrong error message, expected = %q, got = %q", expected, err.Message)
+			}
+		}
+	}
+}
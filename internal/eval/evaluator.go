@@ -0,0 +1,149 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package eval
+
+import (
+	"context"
+
+	"github.com/suenchunyu/snow-lang/internal/ast"
+	"github.com/suenchunyu/snow-lang/internal/diag"
+	"github.com/suenchunyu/snow-lang/internal/object"
+)
+
+// Evaluator walks the AST and evaluates it, its behaviour is configured by
+// options passed to New.
+type Evaluator struct {
+	// checked makes integer arithmetic raise an error on overflow instead
+	// of promoting the result to arbitrary precision.
+	checked bool
+
+	// maxDepth is the maximum number of nested function calls.
+	maxDepth int
+
+	// budget is the maximum number of steps an evaluation may take, zero
+	// means unlimited.
+	budget int
+
+	// ctx and steps belong to the running evaluation.
+	ctx   context.Context
+	steps int
+
+	// stack holds a frame for each function call being evaluated.
+	stack []object.Frame
+}
+
+type Option func(e *Evaluator)
+
+// WithCheckedArithmetic makes integer overflow a runtime error.
+func WithCheckedArithmetic() Option {
+	return func(e *Evaluator) {
+		e.checked = true
+	}
+}
+
+// WithStepBudget limits an evaluation to n steps, a step is a function call,
+// a loop iteration or a block statement. Evaluations taking more steps fail
+// with a budget exceeded error.
+func WithStepBudget(n int) Option {
+	return func(e *Evaluator) {
+		e.budget = n
+	}
+}
+
+// WithMaxCallDepth limits the number of nested function calls to n, deeper
+// calls fail with a maximum call depth exceeded error. It's DefaultMaxCallDepth
+// by default and when n isn't positive, the depth is never unlimited as the
+// Go stack would overflow first.
+func WithMaxCallDepth(n int) Option {
+	return func(e *Evaluator) {
+		if n <= 0 {
+			n = DefaultMaxCallDepth
+		}
+		e.maxDepth = n
+	}
+}
+
+// DefaultMaxCallDepth keeps runaway recursion far from exhausting the Go
+// stack, which would crash the host program.
+const DefaultMaxCallDepth = 10000
+
+func New(opts ...Option) *Evaluator {
+	e := &Evaluator{maxDepth: DefaultMaxCallDepth}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Eval evaluates node in env with the default options.
+func Eval(node ast.Node, env *object.Environment) object.Object {
+	return New().Eval(node, env)
+}
+
+// Eval evaluates node in env. A Go panic raised by a bug of the evaluator
+// or a builtin doesn't crash the host program, it's returned as an error.
+func (e *Evaluator) Eval(node ast.Node, env *object.Environment) object.Object {
+	return e.EvalContext(context.Background(), node, env)
+}
+
+// EvalContext is like Eval, but the evaluation stops with a cancelled error
+// once ctx is done. A builtin may evaluate again while an evaluation runs,
+// the outer one gets its context and steps back once the inner one returns.
+func (e *Evaluator) EvalContext(ctx context.Context, node ast.Node, env *object.Environment) (result object.Object) {
+	depth := len(e.stack)
+	outerCtx, outerSteps := e.ctx, e.steps
+	e.ctx, e.steps = ctx, 0
+
+	defer func() {
+		e.ctx, e.steps = outerCtx, outerSteps
+		if r := recover(); r != nil {
+			err := throw(diag.CodeInternal, "internal error: %v", r)
+			err.Trace = e.trace()
+			e.stack = e.stack[:depth]
+			result = err
+		}
+	}()
+
+	return e.eval(node, env)
+}
+
+// step accounts for one step of the evaluation, it returns an error if the
+// evaluation has to stop.
+func (e *Evaluator) step() *object.Error {
+	e.steps++
+	if e.budget > 0 && e.steps > e.budget {
+		return throw(diag.CodeBudgetExceeded, "step budget exceeded (%d steps)", e.budget)
+	}
+
+	select {
+	case <-e.ctx.Done():
+		return throw(diag.CodeCancelled, "execution cancelled: %v", e.ctx.Err())
+	default:
+		return nil
+	}
+}
+
+// trace returns a copy of the call stack, innermost call first.
+func (e *Evaluator) trace() []object.Frame {
+	trace := make([]object.Frame, 0, len(e.stack))
+	for idx := len(e.stack) - 1; idx >= 0; idx-- {
+		trace = append(trace, e.stack[idx])
+	}
+	return trace
+}
@@ -0,0 +1,286 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package host
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+
+	"github.com/suenchunyu/snow-lang/internal/diag"
+	"github.com/suenchunyu/snow-lang/internal/eval"
+	"github.com/suenchunyu/snow-lang/internal/object"
+)
+
+var (
+	objectType = reflect.TypeOf((*object.Object)(nil)).Elem()
+	errorType  = reflect.TypeOf((*error)(nil)).Elem()
+	bigIntType = reflect.TypeOf((*big.Int)(nil))
+)
+
+// Bind wraps the Go function fn into a builtin named name. The arguments of
+// a call are converted to the parameter types of fn with ToGo and its result
+// with ToObject. fn may return nothing, a value, an error, or a value and an
+// error; a non-nil error is raised as a runtime error of the script.
+func Bind(name string, fn interface{}) (*object.Builtin, error) {
+	rv := reflect.ValueOf(fn)
+	if rv.Kind() != reflect.Func || rv.IsNil() {
+		return nil, fmt.Errorf("cannot bind %T as function %s", fn, name)
+	}
+
+	ft := rv.Type()
+	switch {
+	case ft.NumOut() > 2,
+		ft.NumOut() == 2 && ft.Out(1) != errorType:
+		return nil, fmt.Errorf("cannot bind %s as function %s: it must return at most a value and an error", ft, name)
+	}
+
+	b := &binding{name: name, fn: rv, ft: ft}
+	return &object.Builtin{Fn: b.call}, nil
+}
+
+type binding struct {
+	name string
+	fn   reflect.Value
+	ft   reflect.Type
+}
+
+func (b *binding) call(args ...object.Object) object.Object {
+	in, err := b.arguments(args)
+	if err != nil {
+		return err
+	}
+
+	out := b.fn.Call(in)
+
+	if len(out) > 0 && b.ft.Out(len(out)-1) == errorType {
+		if err, _ := out[len(out)-1].Interface().(error); err != nil {
+			return &object.Error{Code: diag.CodeRuntime, Message: err.Error()}
+		}
+		out = out[:len(out)-1]
+	}
+
+	if len(out) == 0 {
+		return eval.Null
+	}
+
+	result, convErr := ToObject(out[0].Interface())
+	if convErr != nil {
+		return &object.Error{Code: diag.CodeTypeMismatch, Message: fmt.Sprintf("result of %s: %s", b.name, convErr)}
+	}
+	return result
+}
+
+func (b *binding) arguments(args []object.Object) ([]reflect.Value, *object.Error) {
+	fixed := b.ft.NumIn()
+	if b.ft.IsVariadic() {
+		fixed--
+	}
+
+	if len(args) < fixed || (!b.ft.IsVariadic() && len(args) > fixed) {
+		expected := fmt.Sprintf("%d", fixed)
+		if b.ft.IsVariadic() {
+			expected = "at least " + expected
+		}
+		return nil, &object.Error{
+			Code:    diag.CodeArity,
+			Message: fmt.Sprintf("wrong number of arguments to %s: expected %s, got %d", b.name, expected, len(args)),
+		}
+	}
+
+	in := make([]reflect.Value, 0, len(args))
+	for idx, arg := range args {
+		var t reflect.Type
+		if idx < fixed {
+			t = b.ft.In(idx)
+		} else {
+			t = b.ft.In(fixed).Elem()
+		}
+
+		v, err := ToGo(arg, t)
+		if err != nil {
+			return nil, &object.Error{
+				Code:    diag.CodeTypeMismatch,
+				Message: fmt.Sprintf("argument %d to %s: %s", idx+1, b.name, err),
+			}
+		}
+		in = append(in, v)
+	}
+
+	return in, nil
+}
+
+// ToGo converts obj to a Go value of type t. Integers convert to any integer
+// type they fit into and to *big.Int, numbers to float types, arrays to slices
+// and arrays, hashes to maps, and Null to nil pointers, slices and maps. Host
+// objects convert to the Go value they wrap. Parameters of type interface{}
+// receive the value converted by FromObject, parameters of type object.Object
+// the object itself.
+func ToGo(obj object.Object, t reflect.Type) (reflect.Value, error) {
+	if t == objectType {
+		return reflect.ValueOf(&obj).Elem(), nil
+	}
+
+	if t == bigIntType {
+		switch n := obj.(type) {
+		case *object.Integer:
+			return reflect.ValueOf(big.NewInt(n.Value)), nil
+		case *object.BigInt:
+			return reflect.ValueOf(new(big.Int).Set(n.Value)), nil
+		}
+	}
+
+	if o, ok := obj.(*Object); ok && o.value.Type().AssignableTo(t) {
+		out := reflect.New(t).Elem()
+		out.Set(o.value)
+		return out, nil
+	}
+
+	if _, ok := obj.(*object.Null); ok {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+			return reflect.Zero(t), nil
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		if t.NumMethod() != 0 {
+			break
+		}
+		v, err := FromObject(obj)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		out := reflect.New(t).Elem()
+		if v != nil {
+			out.Set(reflect.ValueOf(v))
+		}
+		return out, nil
+	case reflect.Bool:
+		if b, ok := obj.(*object.Boolean); ok {
+			return reflect.ValueOf(b.Value).Convert(t), nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i, ok := obj.(*object.BigInt); ok {
+			return reflect.Value{}, fmt.Errorf("%s overflows %s", i.Value, t)
+		}
+		if i, ok := obj.(*object.Integer); ok {
+			out := reflect.New(t).Elem()
+			if out.OverflowInt(i.Value) {
+				return reflect.Value{}, fmt.Errorf("%d overflows %s", i.Value, t)
+			}
+			out.SetInt(i.Value)
+			return out, nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if i, ok := obj.(*object.BigInt); ok {
+			out := reflect.New(t).Elem()
+			if !i.Value.IsUint64() || out.OverflowUint(i.Value.Uint64()) {
+				return reflect.Value{}, fmt.Errorf("%s overflows %s", i.Value, t)
+			}
+			out.SetUint(i.Value.Uint64())
+			return out, nil
+		}
+		if i, ok := obj.(*object.Integer); ok {
+			out := reflect.New(t).Elem()
+			if i.Value < 0 || out.OverflowUint(uint64(i.Value)) {
+				return reflect.Value{}, fmt.Errorf("%d overflows %s", i.Value, t)
+			}
+			out.SetUint(uint64(i.Value))
+			return out, nil
+		}
+	case reflect.Float32, reflect.Float64:
+		switch n := obj.(type) {
+		case *object.Float:
+			return reflect.ValueOf(n.Value).Convert(t), nil
+		case *object.Integer:
+			return reflect.ValueOf(float64(n.Value)).Convert(t), nil
+		case *object.BigInt:
+			f, _ := new(big.Float).SetInt(n.Value).Float64()
+			return reflect.ValueOf(f).Convert(t), nil
+		}
+	case reflect.String:
+		if s, ok := obj.(*object.String); ok {
+			return reflect.ValueOf(s.Value).Convert(t), nil
+		}
+	case reflect.Slice:
+		if arr, ok := obj.(*object.Array); ok {
+			out := reflect.MakeSlice(t, len(arr.Elements), len(arr.Elements))
+			return out, convertElements(arr.Elements, out)
+		}
+	case reflect.Array:
+		if arr, ok := obj.(*object.Array); ok {
+			if len(arr.Elements) != t.Len() {
+				return reflect.Value{}, fmt.Errorf("cannot use Array of length %d as %s", len(arr.Elements), t)
+			}
+			out := reflect.New(t).Elem()
+			return out, convertElements(arr.Elements, out)
+		}
+	case reflect.Map:
+		if hash, ok := obj.(*object.Hash); ok {
+			return hashToGo(hash, t)
+		}
+	case reflect.Ptr:
+		elem, err := ToGo(obj, t.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		out := reflect.New(t.Elem())
+		out.Elem().Set(elem)
+		return out, nil
+	}
+
+	return reflect.Value{}, fmt.Errorf("cannot use %s as %s", obj.Type(), t)
+}
+
+func convertElements(elements []object.Object, out reflect.Value) error {
+	for idx, element := range elements {
+		v, err := ToGo(element, out.Type().Elem())
+		if err != nil {
+			return fmt.Errorf("element %d: %w", idx, err)
+		}
+		out.Index(idx).Set(v)
+	}
+	return nil
+}
+
+func hashToGo(hash *object.Hash, t reflect.Type) (reflect.Value, error) {
+	out := reflect.MakeMapWithSize(t, hash.Len())
+
+	for _, pair := range hash.Pairs() {
+		key := pair.Key.Inspect()
+		if s, ok := pair.Key.(*object.String); ok {
+			key = strconv.Quote(s.Value)
+		}
+
+		k, err := ToGo(pair.Key, t.Key())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("key %s: %w", key, err)
+		}
+		v, err := ToGo(pair.Value, t.Elem())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("value of %s: %w", key, err)
+		}
+		out.SetMapIndex(k, v)
+	}
+
+	return out, nil
+}
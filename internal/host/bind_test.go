@@ -0,0 +1,168 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package host_test
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/suenchunyu/snow-lang/internal/diag"
+	"github.com/suenchunyu/snow-lang/internal/eval"
+	"github.com/suenchunyu/snow-lang/internal/host"
+	"github.com/suenchunyu/snow-lang/internal/lexer"
+	"github.com/suenchunyu/snow-lang/internal/object"
+	"github.com/suenchunyu/snow-lang/internal/parser"
+)
+
+func evalWith(t *testing.T, input string, funcs map[string]interface{}) object.Object {
+	env := object.NewEnv()
+	for name, fn := range funcs {
+		builtin, err := host.Bind(name, fn)
+		if err != nil {
+			t.Fatalf("Bind(%s) failed: %s", name, err)
+		}
+		env.Set(name, builtin)
+	}
+
+	program := parser.New(lexer.New(input)).Parse()
+	return eval.Eval(program, env)
+}
+
+func TestBind(t *testing.T) {
+	var called bool
+
+	funcs := map[string]interface{}{
+		"repeat": strings.Repeat,
+		"join":   func(parts []string, sep string) string { return strings.Join(parts, sep) },
+		"not":    func(b bool) bool { return !b },
+		"small":  func(n uint8) int { return int(n) },
+		"sum": func(base int, ns ...int) int {
+			for _, n := range ns {
+				base += n
+			}
+			return base
+		},
+		"keys":    func(m map[string]int) int { return len(m) },
+		"fail":    func() (int, error) { return 0, errors.New("boom") },
+		"nothing": func() { called = true },
+		"any":     func(v interface{}) string { return reflect.TypeOf(v).String() },
+		"raw":     func(obj object.Object) object.Object { return obj },
+		"ptr":     func(p *int) bool { return p == nil },
+		"half":    func(f float32) float64 { return float64(f) / 2 },
+		"double":  func(n *big.Int) *big.Int { return n.Mul(n, big.NewInt(2)) },
+		"max":     func(n uint64) uint64 { return n },
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`repeat("ab", 3)`, "ababab"},
+		{`join(["a", "b"], "-")`, "a-b"},
+		{`not(false)`, "true"},
+		{`small(255)`, "255"},
+		{`sum(1)`, "1"},
+		{`sum(1, 2, 3)`, "6"},
+		{`keys({"a": 1, "b": 2})`, "2"},
+		{`any({"a": [1]})`, "map[string]interface {}"},
+		{`raw(fn(x) { x })`, "fn(x) x"},
+		{`ptr(nothing())`, "true"},
+		{`ptr(1)`, "false"},
+		{`nothing()`, "null"},
+		{`half(3)`, "1.5"},
+		{`half(0.5)`, "0.25"},
+		{`half("1")`, "argument 1 to half: cannot use String as float32"},
+		{`double(3)`, "6"},
+		{`double(9223372036854775807)`, "18446744073709551614"},
+		{`let n = 9223372036854775808; double(n); n`, "9223372036854775808"},
+		{`max(18446744073709551615)`, "18446744073709551615"},
+		{`max(18446744073709551616)`, "argument 1 to max: 18446744073709551616 overflows uint64"},
+		{`small(9223372036854775808)`, "argument 1 to small: 9223372036854775808 overflows uint8"},
+		{`repeat("a", 9223372036854775808)`, "argument 2 to repeat: 9223372036854775808 overflows int"},
+		{`repeat("ab")`, "wrong number of arguments to repeat: expected 2, got 1"},
+		{`sum()`, "wrong number of arguments to sum: expected at least 1, got 0"},
+		{`repeat(1, 2)`, "argument 1 to repeat: cannot use Integer as string"},
+		{`small(256)`, "argument 1 to small: 256 overflows uint8"},
+		{`small(-1)`, "argument 1 to small: -1 overflows uint8"},
+		{`join(["a", 1], "")`, "argument 1 to join: element 1: cannot use Integer as string"},
+		{`keys({"a": "b"})`, `argument 1 to keys: value of "a": cannot use String as int`},
+		{`fail()`, "boom"},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalWith(t, tt.input, funcs)
+
+		got := evaluated.Inspect()
+		if err, ok := evaluated.(*object.Error); ok {
+			got = err.Message
+		}
+
+		if got != tt.expected {
+			t.Errorf("%s wrong, expected = %q, got = %q", tt.input, tt.expected, got)
+		}
+	}
+
+	if !called {
+		t.Error("nothing() was never called")
+	}
+}
+
+func TestBindErrorCodes(t *testing.T) {
+	funcs := map[string]interface{}{
+		"id": func(n int) int { return n },
+	}
+
+	tests := []struct {
+		input    string
+		expected diag.Code
+	}{
+		{"id()", diag.CodeArity},
+		{`id("1")`, diag.CodeTypeMismatch},
+	}
+
+	for _, tt := range tests {
+		err, ok := evalWith(t, tt.input, funcs).(*object.Error)
+		if !ok {
+			t.Errorf("%s didn't fail", tt.input)
+			continue
+		}
+
+		if err.Code != tt.expected {
+			t.Errorf("%s wrong code, expected = %q, got = %q", tt.input, tt.expected, err.Code)
+		}
+	}
+}
+
+func TestBindRejects(t *testing.T) {
+	inputs := []interface{}{
+		42,
+		(func())(nil),
+		func() (int, int) { return 0, 0 },
+		func() (int, string, error) { return 0, "", nil },
+	}
+
+	for _, input := range inputs {
+		if _, err := host.Bind("f", input); err == nil {
+			t.Errorf("Bind(%T) didn't fail", input)
+		}
+	}
+}
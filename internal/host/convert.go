@@ -0,0 +1,306 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package host converts values between Go and Snow for programs embedding
+// the interpreter.
+package host
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+
+	"github.com/suenchunyu/snow-lang/internal/diag"
+	"github.com/suenchunyu/snow-lang/internal/eval"
+	"github.com/suenchunyu/snow-lang/internal/object"
+)
+
+// Func is a Go function callable from Snow.
+type Func func(args ...object.Object) (object.Object, error)
+
+// ToObject converts a Go value to a Snow object, the conversions are
+// documented by snow.ToValue which exposes it.
+func ToObject(v interface{}) (object.Object, error) {
+	return newConverter(nil).toObject(v)
+}
+
+// converter converts Go values to Snow objects.
+type converter struct {
+	// seen holds the values being converted by the callers so that a value
+	// containing itself fails instead of recursing forever.
+	seen map[visit]bool
+	// parent is the Object whose field is converted, structs met in the
+	// field are exposed as Objects sharing its allow-list. Structs can't be
+	// converted without a parent.
+	parent *Object
+}
+
+func newConverter(parent *Object) *converter {
+	return &converter{seen: make(map[visit]bool), parent: parent}
+}
+
+// visit identifies a map, slice or pointer being converted, slices sharing
+// an array but of different lengths are different values.
+type visit struct {
+	typ reflect.Type
+	ptr uintptr
+	len int
+}
+
+func (c *converter) toObject(v interface{}) (object.Object, error) {
+	switch v := v.(type) {
+	case nil:
+		return eval.Null, nil
+	case object.Object:
+		return v, nil
+	case bool:
+		return nativeBool(v), nil
+	case int:
+		return &object.Integer{Value: int64(v)}, nil
+	case int64:
+		return &object.Integer{Value: v}, nil
+	case float64:
+		return &object.Float{Value: v}, nil
+	case *big.Int:
+		if v == nil {
+			return eval.Null, nil
+		}
+		return object.NewInteger(new(big.Int).Set(v)), nil
+	case big.Int:
+		return object.NewInteger(new(big.Int).Set(&v)), nil
+	case string:
+		return &object.String{Value: v}, nil
+	case Func:
+		return NewBuiltin(v), nil
+	case func(args ...object.Object) (object.Object, error):
+		return NewBuiltin(v), nil
+	}
+
+	return c.reflectToObject(reflect.ValueOf(v))
+}
+
+// value converts rv, a struct stored in a slice or a field of a pointed-to
+// struct is converted by its address to keep pointer receiver methods.
+func (c *converter) value(rv reflect.Value) (object.Object, error) {
+	if rv.Kind() == reflect.Struct && rv.CanAddr() {
+		rv = rv.Addr()
+	}
+	return c.toObject(rv.Interface())
+}
+
+func (c *converter) reflectToObject(rv reflect.Value) (object.Object, error) {
+	switch rv.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Ptr:
+		if rv.IsNil() {
+			break
+		}
+		key := visit{typ: rv.Type(), ptr: rv.Pointer()}
+		if rv.Kind() == reflect.Slice {
+			key.len = rv.Len()
+		}
+		if c.seen[key] {
+			return nil, fmt.Errorf("cannot convert %s to a Snow value: it contains itself", rv.Type())
+		}
+		c.seen[key] = true
+		defer delete(c.seen, key)
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		return nativeBool(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &object.Integer{Value: rv.Int()}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return object.NewInteger(new(big.Int).SetUint64(rv.Uint())), nil
+	case reflect.Float32, reflect.Float64:
+		return &object.Float{Value: rv.Float()}, nil
+	case reflect.String:
+		return &object.String{Value: rv.String()}, nil
+	case reflect.Slice, reflect.Array:
+		elements := make([]object.Object, 0, rv.Len())
+		for idx := 0; idx < rv.Len(); idx++ {
+			element, err := c.value(rv.Index(idx))
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, element)
+		}
+		return &object.Array{Elements: elements}, nil
+	case reflect.Map:
+		return c.mapToHash(rv)
+	case reflect.Struct:
+		if c.parent != nil {
+			return &Object{value: rv, members: c.parent.members}, nil
+		}
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return eval.Null, nil
+		}
+		if c.parent != nil && rv.Kind() == reflect.Ptr && rv.Elem().Kind() == reflect.Struct {
+			return &Object{value: rv, members: c.parent.members}, nil
+		}
+		return c.toObject(rv.Elem().Interface())
+	}
+
+	return nil, fmt.Errorf("cannot convert %s to a Snow value", rv.Type())
+}
+
+// mapToHash converts a map, its keys are sorted by their formatted value so
+// that the hash has the same order every time.
+func (c *converter) mapToHash(rv reflect.Value) (object.Object, error) {
+	keys := rv.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+
+	hash := object.NewHash()
+	for _, key := range keys {
+		k, err := c.toObject(key.Interface())
+		if err != nil {
+			return nil, err
+		}
+
+		hashable, ok := k.(object.Hashable)
+		if !ok {
+			return nil, fmt.Errorf("cannot convert %s to a Snow value: unusable as hash key: %s", rv.Type(), k.Type())
+		}
+
+		value, err := c.toObject(rv.MapIndex(key).Interface())
+		if err != nil {
+			return nil, err
+		}
+		hash.Set(hashable, value)
+	}
+
+	return hash, nil
+}
+
+// FromObject converts a Snow object to a Go value, the conversions are
+// documented by snow.FromValue which exposes it.
+func FromObject(obj object.Object) (interface{}, error) {
+	return fromObject(obj, make(map[object.Object]bool))
+}
+
+// fromObject converts obj, seen holds the containers being converted by the
+// callers so that a container holding itself fails instead of recursing
+// forever.
+func fromObject(obj object.Object, seen map[object.Object]bool) (interface{}, error) {
+	switch obj := obj.(type) {
+	case nil, *object.Null:
+		return nil, nil
+	case *object.Boolean:
+		return obj.Value, nil
+	case *object.Integer:
+		return obj.Value, nil
+	case *object.BigInt:
+		return new(big.Int).Set(obj.Value), nil
+	case *object.Float:
+		return obj.Value, nil
+	case *object.String:
+		return obj.Value, nil
+	case *object.Array:
+		if seen[obj] {
+			return nil, fmt.Errorf("cannot convert %s to a Go value: it contains itself", obj.Type())
+		}
+		seen[obj] = true
+		defer delete(seen, obj)
+
+		out := make([]interface{}, 0, len(obj.Elements))
+		for _, element := range obj.Elements {
+			v, err := fromObject(element, seen)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+		}
+		return out, nil
+	case *object.Hash:
+		if seen[obj] {
+			return nil, fmt.Errorf("cannot convert %s to a Go value: it contains itself", obj.Type())
+		}
+		seen[obj] = true
+		defer delete(seen, obj)
+
+		return hashToMap(obj, seen)
+	case *Object:
+		return obj.Value(), nil
+	default:
+		return nil, fmt.Errorf("cannot convert %s to a Go value", obj.Type())
+	}
+}
+
+func hashToMap(hash *object.Hash, seen map[object.Object]bool) (interface{}, error) {
+	pairs := hash.Pairs()
+
+	strings := true
+	for _, pair := range pairs {
+		if _, ok := pair.Key.(*object.String); !ok {
+			strings = false
+			break
+		}
+	}
+
+	if strings {
+		out := make(map[string]interface{}, len(pairs))
+		for _, pair := range pairs {
+			v, err := fromObject(pair.Value, seen)
+			if err != nil {
+				return nil, err
+			}
+			out[pair.Key.(*object.String).Value] = v
+		}
+		return out, nil
+	}
+
+	out := make(map[interface{}]interface{}, len(pairs))
+	for _, pair := range pairs {
+		k, err := fromObject(pair.Key, seen)
+		if err != nil {
+			return nil, err
+		}
+		v, err := fromObject(pair.Value, seen)
+		if err != nil {
+			return nil, err
+		}
+		out[k] = v
+	}
+	return out, nil
+}
+
+// NewBuiltin wraps fn into a builtin function, an error returned by fn is
+// raised as a runtime error of the script.
+func NewBuiltin(fn Func) *object.Builtin {
+	return &object.Builtin{Fn: func(args ...object.Object) object.Object {
+		result, err := fn(args...)
+		if err != nil {
+			return &object.Error{Code: diag.CodeRuntime, Message: err.Error()}
+		}
+		if result == nil {
+			return eval.Null
+		}
+		return result
+	}}
+}
+
+func nativeBool(v bool) object.Object {
+	if v {
+		return eval.True
+	}
+	return eval.False
+}
@@ -0,0 +1,187 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package host_test
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/suenchunyu/snow-lang/internal/eval"
+	"github.com/suenchunyu/snow-lang/internal/host"
+	"github.com/suenchunyu/snow-lang/internal/object"
+)
+
+func TestToObject(t *testing.T) {
+	answer := 42
+	var nilPtr *int
+
+	tests := []struct {
+		input    interface{}
+		expected string
+	}{
+		{nil, "null"},
+		{true, "true"},
+		{int8(-3), "-3"},
+		{uint32(7), "7"},
+		{"snow", "snow"},
+		{3.5, "3.5"},
+		{uint64(math.MaxUint64), "18446744073709551615"},
+		{new(big.Int).Lsh(big.NewInt(1), 70), "1180591620717411303424"},
+		{big.NewInt(7), "7"},
+		{float32(2), "2.0"},
+		{&answer, "42"},
+		{nilPtr, "null"},
+		{[]string{"a", "b"}, `["a", "b"]`},
+		{[2]int{1, 2}, "[1, 2]"},
+		{map[string]int{"b": 2, "a": 1}, `{"a": 1, "b": 2}`},
+		{map[int][]bool{1: {true}}, "{1: [true]}"},
+		{[]interface{}{1, "x", nil}, `[1, "x", null]`},
+	}
+
+	for _, tt := range tests {
+		obj, err := host.ToObject(tt.input)
+		if err != nil {
+			t.Errorf("ToObject(%#v) failed: %s", tt.input, err)
+			continue
+		}
+
+		if obj.Inspect() != tt.expected {
+			t.Errorf("ToObject(%#v) wrong, expected = %s, got = %s", tt.input, tt.expected, obj.Inspect())
+		}
+	}
+}
+
+func TestToObjectSingletons(t *testing.T) {
+	if obj, _ := host.ToObject(true); obj != eval.True {
+		t.Errorf("true is not converted to eval.True")
+	}
+
+	if obj, _ := host.ToObject(nil); obj != eval.Null {
+		t.Errorf("nil is not converted to eval.Null")
+	}
+}
+
+func TestToObjectErrors(t *testing.T) {
+	inputs := []interface{}{
+		complex(1, 2),
+		struct{}{},
+		[]chan int{nil},
+		map[[1]int]int{{1}: 1},
+	}
+
+	for _, input := range inputs {
+		if obj, err := host.ToObject(input); err == nil {
+			t.Errorf("ToObject(%#v) didn't fail, got = %s", input, obj.Inspect())
+		}
+	}
+}
+
+func TestToObjectCycle(t *testing.T) {
+	m := map[string]interface{}{}
+	m["self"] = m
+
+	s := make([]interface{}, 1)
+	s[0] = s
+
+	p := new(interface{})
+	*p = p
+
+	for _, input := range []interface{}{m, s, p, []interface{}{m}} {
+		if _, err := host.ToObject(input); err == nil || !strings.Contains(err.Error(), "contains itself") {
+			t.Errorf("ToObject(%T) expected a cycle error, got = %v", input, err)
+		}
+	}
+
+	// A value shared by two elements isn't a cycle.
+	shared := []int{1}
+	obj, err := host.ToObject(map[string]interface{}{"a": shared, "b": shared})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obj.Inspect() != `{"a": [1], "b": [1]}` {
+		t.Errorf("wrong value, got = %s", obj.Inspect())
+	}
+}
+
+func TestFromObject(t *testing.T) {
+	strings := object.NewHash()
+	strings.Set(&object.String{Value: "a"}, &object.Integer{Value: 1})
+
+	mixed := object.NewHash()
+	mixed.Set(&object.Integer{Value: 1}, eval.True)
+
+	tests := []struct {
+		input    object.Object
+		expected interface{}
+	}{
+		{eval.Null, nil},
+		{eval.False, false},
+		{&object.Integer{Value: 5}, int64(5)},
+		{&object.Float{Value: 0.5}, 0.5},
+		{&object.BigInt{Value: new(big.Int).Lsh(big.NewInt(1), 70)}, new(big.Int).Lsh(big.NewInt(1), 70)},
+		{&object.String{Value: "snow"}, "snow"},
+		{&object.Array{Elements: []object.Object{eval.Null, &object.Integer{Value: 1}}}, []interface{}{nil, int64(1)}},
+		{strings, map[string]interface{}{"a": int64(1)}},
+		{mixed, map[interface{}]interface{}{int64(1): true}},
+	}
+
+	for _, tt := range tests {
+		v, err := host.FromObject(tt.input)
+		if err != nil {
+			t.Errorf("FromObject(%s) failed: %s", tt.input.Inspect(), err)
+			continue
+		}
+
+		if !reflect.DeepEqual(v, tt.expected) {
+			t.Errorf("FromObject(%s) wrong, expected = %#v, got = %#v", tt.input.Inspect(), tt.expected, v)
+		}
+	}
+
+	if _, err := host.FromObject(&object.Builtin{}); err == nil {
+		t.Error("FromObject of a builtin didn't fail")
+	}
+}
+
+func TestFromObjectCycle(t *testing.T) {
+	array := &object.Array{Elements: []object.Object{eval.Null}}
+	array.Elements[0] = array
+
+	hash := object.NewHash()
+	hash.Set(&object.String{Value: "self"}, &object.Array{Elements: []object.Object{hash}})
+
+	for _, obj := range []object.Object{array, hash} {
+		if _, err := host.FromObject(obj); err == nil || !strings.Contains(err.Error(), "contains itself") {
+			t.Errorf("FromObject(%s) expected a cycle error, got = %v", obj.Inspect(), err)
+		}
+	}
+
+	// A value shared by two elements isn't a cycle.
+	shared := &object.Array{Elements: []object.Object{&object.Integer{Value: 1}}}
+	v, err := host.FromObject(&object.Array{Elements: []object.Object{shared, shared}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []interface{}{[]interface{}{int64(1)}, []interface{}{int64(1)}}
+	if !reflect.DeepEqual(v, expected) {
+		t.Errorf("wrong value, expected = %#v, got = %#v", expected, v)
+	}
+}
@@ -0,0 +1,126 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package host
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/suenchunyu/snow-lang/internal/diag"
+	"github.com/suenchunyu/snow-lang/internal/object"
+)
+
+// Object exposes a Go value to scripts, they read its fields by
+// `object.Field` and call its methods by `object.Method(args)`. Only the
+// members on the allow-list are accessible.
+type Object struct {
+	value reflect.Value
+	// members is the allow-list, nil if all members are allowed.
+	members map[string]bool
+}
+
+// NewObject wraps v, a struct or a pointer to one, into an Object. members
+// is the allow-list of the exported fields and methods accessible by scripts,
+// all of them are accessible if it's empty. Methods with a pointer receiver
+// are only accessible if v is a pointer.
+func NewObject(v interface{}, members ...string) (*Object, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() && rv.Elem().Kind() == reflect.Struct ||
+		rv.Kind() == reflect.Struct {
+		o := &Object{value: rv, members: make(map[string]bool)}
+		return o, o.allow(members)
+	}
+	return nil, fmt.Errorf("cannot expose %T as object, it must be a struct or a pointer to a struct", v)
+}
+
+func (o *Object) allow(members []string) error {
+	if len(members) == 0 {
+		o.members = nil
+		return nil
+	}
+
+	for _, name := range members {
+		if !o.hasMember(name) {
+			return fmt.Errorf("cannot expose %s of %s: no such exported field or method", name, o.value.Type())
+		}
+		o.members[name] = true
+	}
+	return nil
+}
+
+// accessible reports whether scripts may access the member name, all the
+// exported members are accessible without an allow-list.
+func (o *Object) accessible(name string) bool {
+	if o.members != nil && !o.members[name] {
+		return false
+	}
+	// a nested object shares the allow-list, which may name members it lacks.
+	return o.hasMember(name)
+}
+
+func (o *Object) hasMember(name string) bool {
+	if method, ok := o.value.Type().MethodByName(name); ok {
+		return method.PkgPath == ""
+	}
+
+	field, ok := o.elem().Type().FieldByName(name)
+	return ok && field.PkgPath == ""
+}
+
+// elem returns the wrapped struct.
+func (o *Object) elem() reflect.Value {
+	return reflect.Indirect(o.value)
+}
+
+// Value returns the wrapped Go value.
+func (o *Object) Value() interface{} {
+	return o.value.Interface()
+}
+
+func (o *Object) Type() object.Type {
+	return object.TypeHostObject
+}
+
+func (o *Object) Inspect() string {
+	return fmt.Sprintf("<%s>", o.value.Type())
+}
+
+// Property returns the field name converted by ToObject, or the method name
+// bound as a builtin. Structs held by the field, directly or in slices, maps
+// and interfaces, are exposed as Objects sharing the allow-list, so
+// `object.Inner.Field` needs both Inner and Field on the list.
+func (o *Object) Property(name string) (object.Object, bool) {
+	if !o.accessible(name) {
+		return nil, false
+	}
+
+	if method := o.value.MethodByName(name); method.IsValid() {
+		builtin, err := Bind(name, method.Interface())
+		if err != nil {
+			return &object.Error{Code: diag.CodeTypeMismatch, Message: err.Error()}, true
+		}
+		return builtin, true
+	}
+
+	value, err := newConverter(o).value(o.elem().FieldByName(name))
+	if err != nil {
+		return &object.Error{Code: diag.CodeTypeMismatch, Message: fmt.Sprintf("field %s: %s", name, err)}, true
+	}
+	return value, true
+}
@@ -0,0 +1,232 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package host_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/suenchunyu/snow-lang/internal/eval"
+	"github.com/suenchunyu/snow-lang/internal/host"
+	"github.com/suenchunyu/snow-lang/internal/lexer"
+	"github.com/suenchunyu/snow-lang/internal/object"
+	"github.com/suenchunyu/snow-lang/internal/parser"
+)
+
+type Meta struct {
+	Tags []string
+}
+
+type Item struct {
+	Name string
+}
+
+func (i *Item) Rename(name string) {
+	i.Name = name
+}
+
+type Request struct {
+	Meta
+	Method  string
+	Path    string
+	Headers map[string]string
+	Parent  *Request
+	Inner   struct{ X int }
+	Items   []Item
+	Index   map[string]Item
+	Any     interface{}
+	secret  string
+	visited int
+}
+
+func (r Request) Header(name string) string {
+	return r.Headers[name]
+}
+
+func (r *Request) Visit() int {
+	r.visited++
+	return r.visited
+}
+
+func (r Request) Secret() string {
+	return r.secret
+}
+
+func (r Request) Same(other *Request) bool {
+	return r.Path == other.Path
+}
+
+func evalObject(t *testing.T, input string, obj *host.Object) object.Object {
+	env := object.NewEnv()
+	env.Set("req", obj)
+
+	program := parser.New(lexer.New(input)).Parse()
+	return eval.Eval(program, env)
+}
+
+func TestObjectMembers(t *testing.T) {
+	req := &Request{
+		Meta:    Meta{Tags: []string{"api"}},
+		Method:  "GET",
+		Path:    "/snow",
+		Headers: map[string]string{"Accept": "text/plain"},
+		Parent:  &Request{Path: "/"},
+		Inner:   struct{ X int }{X: 7},
+		Items:   []Item{{Name: "a"}, {Name: "b"}},
+		Index:   map[string]Item{"c": {Name: "c"}},
+		Any:     Item{Name: "d"},
+		secret:  "hidden",
+	}
+
+	obj, err := host.NewObject(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`req.Method + " " + req.Path`, "GET /snow"},
+		{`req.Headers["Accept"]`, "text/plain"},
+		{`req.Header("Accept")`, "text/plain"},
+		{`req.Visit(); req.Visit()`, "2"},
+		{`req.Tags[0]`, "api"},
+		{`req.Same(req)`, "true"},
+		{`req`, "<*host_test.Request>"},
+		{`req.secret`, "undefined property: secret"},
+		{`req.Nope`, "undefined property: Nope"},
+		{`req.Inner.X`, "7"},
+		{`req.Inner`, "<*struct { X int }>"},
+		{`req.Meta.Tags[0]`, "api"},
+		{`req.Parent.Path`, "/"},
+		{`req.Parent.Visit()`, "1"},
+		{`req.Inner.Y`, "undefined property: Y"},
+		{`len(req.Items)`, "2"},
+		{`req.Items[1].Name`, "b"},
+		{`req.Items[0]`, "<*host_test.Item>"},
+		{`req.Items[0].Rename("z"); req.Items[0].Name`, "z"},
+		{`req.Index["c"].Name`, "c"},
+		{`req.Any.Name`, "d"},
+		{`req.Header(1)`, "argument 1 to Header: cannot use Integer as string"},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalObject(t, tt.input, obj)
+
+		got := evaluated.Inspect()
+		if err, ok := evaluated.(*object.Error); ok {
+			got = err.Message
+		}
+
+		if got != tt.expected {
+			t.Errorf("%s wrong, expected = %q, got = %q", tt.input, tt.expected, got)
+		}
+	}
+
+	if req.visited != 2 {
+		t.Errorf("method with pointer receiver didn't modify the value, visited = %d", req.visited)
+	}
+	if req.Parent.visited != 1 {
+		t.Errorf("method of a nested object didn't modify the value, visited = %d", req.Parent.visited)
+	}
+	if req.Items[0].Name != "z" {
+		t.Errorf("method of a slice element didn't modify the value, name = %q", req.Items[0].Name)
+	}
+}
+
+func TestObjectAllowList(t *testing.T) {
+	req := Request{Method: "POST", Parent: &Request{Path: "/", Method: "GET"}, Inner: struct{ X int }{X: 7}, secret: "hidden"}
+	req.Items = []Item{{Name: "a"}}
+	obj, err := host.NewObject(req, "Method", "Header", "Parent", "Inner", "Items")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`req.Method`, "POST"},
+		{`req.Header("x")`, ""},
+		{`req.Path`, "undefined property: Path"},
+		{`req.Secret()`, "undefined property: Secret"},
+		// pointer receivers need a pointer
+		{`req.Visit()`, "undefined property: Visit"},
+		// nested objects share the allow-list
+		{`req.Parent.Method`, "GET"},
+		{`req.Parent.Path`, "undefined property: Path"},
+		{`req.Parent.Parent`, "null"},
+		{`req.Inner.X`, "undefined property: X"},
+		{`req.Items[0]`, "<*host_test.Item>"},
+		{`req.Items[0].Name`, "undefined property: Name"},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalObject(t, tt.input, obj)
+
+		got := evaluated.Inspect()
+		if err, ok := evaluated.(*object.Error); ok {
+			got = err.Message
+		}
+
+		if got != tt.expected {
+			t.Errorf("%s wrong, expected = %q, got = %q", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestNewObjectErrors(t *testing.T) {
+	tests := []struct {
+		value   interface{}
+		members []string
+		message string
+	}{
+		{42, nil, "cannot expose int as object"},
+		{(*Request)(nil), nil, "cannot expose *host_test.Request as object"},
+		{Request{}, []string{"secret"}, "cannot expose secret of host_test.Request"},
+		{Request{}, []string{"Visit"}, "cannot expose Visit of host_test.Request"},
+	}
+
+	for _, tt := range tests {
+		_, err := host.NewObject(tt.value, tt.members...)
+		if err == nil || !strings.HasPrefix(err.Error(), tt.message) {
+			t.Errorf("NewObject(%T, %v) wrong error, expected = %q, got = %v", tt.value, tt.members, tt.message, err)
+		}
+	}
+}
+
+func TestObjectConversions(t *testing.T) {
+	req := &Request{Path: "/"}
+
+	obj, err := host.NewObject(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := host.FromObject(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v != req {
+		t.Errorf("FromObject doesn't return the wrapped value, got = %s", fmt.Sprint(v))
+	}
+}
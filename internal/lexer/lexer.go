@@ -18,22 +18,47 @@
 
 package lexer
 
-import "github.com/suenchunyu/snow-lang/internal/token"
+import (
+	"github.com/suenchunyu/snow-lang/internal/diag"
+	"github.com/suenchunyu/snow-lang/internal/token"
+)
 
 type Lexer struct {
+	file  string
 	input string
 	pos   int
 	rpos  int
 	ch    byte
+
+	// line and col locate ch in the input.
+	line int
+	col  int
+
+	// templates holds the number of open braces in each template expression
+	// being lexed, the innermost one last.
+	templates []int
+
+	diagnostics []*diag.Diagnostic
 }
 
 func New(input string) *Lexer {
-	l := &Lexer{input: input}
+	return NewFile("", input)
+}
+
+// NewFile returns a Lexer whose token positions refer to the named file.
+func NewFile(file, input string) *Lexer {
+	l := &Lexer{file: file, input: input, line: 1}
 	l.readChar()
+	l.skipShebang()
 	return l
 }
 
 func (l *Lexer) readChar() {
+	if l.ch == '\n' {
+		l.line += 1
+		l.col = 0
+	}
+
 	if l.rpos >= len(l.input) {
 		// 'NUL' for ASCII
 		l.ch = 0
@@ -43,6 +68,17 @@ func (l *Lexer) readChar() {
 
 	l.pos = l.rpos
 	l.rpos += 1
+	l.col += 1
+}
+
+// position returns the position of the current character.
+func (l *Lexer) position() token.Pos {
+	return token.Pos{
+		File:   l.file,
+		Offset: l.pos,
+		Line:   l.line,
+		Column: l.col,
+	}
 }
 
 func (l *Lexer) peekChar() byte {
@@ -54,9 +90,16 @@ func (l *Lexer) peekChar() byte {
 }
 
 func (l *Lexer) NextToken() *token.Token {
-	tok := new(token.Token)
+	comments := l.skipTrivia()
 
-	l.skipWhitespace()
+	tok := l.next()
+	tok.Leading = comments
+	return tok
+}
+
+func (l *Lexer) next() *token.Token {
+	tok := new(token.Token)
+	start := l.position()
 
 	switch l.ch {
 	case '=':
@@ -71,9 +114,9 @@ func (l *Lexer) NextToken() *token.Token {
 			tok = token.New(token.FlagAssign, l.ch)
 		}
 	case '+':
-		tok = token.New(token.FlagPlus, l.ch)
+		tok = l.readOperator(token.FlagPlus, token.FlagPlusAssign)
 	case '-':
-		tok = token.New(token.FlagMinus, l.ch)
+		tok = l.readOperator(token.FlagMinus, token.FlagMinusAssign)
 	case '!':
 		if l.peekChar() == '=' {
 			ch := l.ch
@@ -87,11 +130,25 @@ func (l *Lexer) NextToken() *token.Token {
 		}
 
 	case '/':
-		tok = token.New(token.FlagSlash, l.ch)
+		tok = l.readOperator(token.FlagSlash, token.FlagSlashAssign)
 	case '*':
-		tok = token.New(token.FlagAsterisk, l.ch)
+		tok = l.readOperator(token.FlagAsterisk, token.FlagAsteriskAssign)
+	case '%':
+		tok = l.readOperator(token.FlagPercent, token.FlagPercentAssign)
+	case '&':
+		tok = l.readDouble(token.FlagAmpersand, token.FlagAnd)
+	case '|':
+		tok = l.readDouble(token.FlagPipe, token.FlagOr)
+	case '^':
+		tok = token.New(token.FlagCaret, l.ch)
+	case '~':
+		tok = token.New(token.FlagTilde, l.ch)
 	case ';':
 		tok = token.New(token.FlagSemicolon, l.ch)
+	case ':':
+		tok = token.New(token.FlagColon, l.ch)
+	case '.':
+		tok = l.readDot()
 	case '(':
 		tok = token.New(token.FlagLParen, l.ch)
 	case ')':
@@ -99,20 +156,46 @@ func (l *Lexer) NextToken() *token.Token {
 	case ',':
 		tok = token.New(token.FlagComma, l.ch)
 	case '{':
+		if n := len(l.templates); n > 0 {
+			l.templates[n-1]++
+		}
 		tok = token.New(token.FlagLBrace, l.ch)
 	case '}':
+		if n := len(l.templates); n > 0 {
+			// the brace closing a template expression resumes the string.
+			if l.templates[n-1] == 0 {
+				l.templates = l.templates[:n-1]
+				tok.Literal, tok.Flag = l.readTemplateText()
+				tok.Span = token.Span{Start: start, End: l.position()}
+				return tok
+			}
+			l.templates[n-1]--
+		}
 		tok = token.New(token.FlagRBrace, l.ch)
 	case '<':
-		tok = token.New(token.FlagLessThan, l.ch)
+		if l.peekChar() == '<' {
+			tok = l.readDouble(token.FlagLessThan, token.FlagShiftLeft)
+		} else {
+			tok = l.readOperator(token.FlagLessThan, token.FlagLessEqual)
+		}
 	case '>':
-		tok = token.New(token.FlagGreaterThan, l.ch)
+		if l.peekChar() == '>' {
+			tok = l.readDouble(token.FlagGreaterThan, token.FlagShiftRight)
+		} else {
+			tok = l.readOperator(token.FlagGreaterThan, token.FlagGreaterEqual)
+		}
 	case '[':
 		tok = token.New(token.FlagLBracket, l.ch)
 	case ']':
 		tok = token.New(token.FlagRBracket, l.ch)
 	case '"':
-		tok.Flag = token.FlagString
-		tok.Literal = l.readString()
+		tok.Literal, tok.Flag = l.readString()
+		tok.Span = token.Span{Start: start, End: l.position()}
+		return tok
+	case '`':
+		tok.Literal, tok.Flag = l.readRawString()
+		tok.Span = token.Span{Start: start, End: l.position()}
+		return tok
 	case 0:
 		tok.Literal = ""
 		tok.Flag = token.FlagEOF
@@ -120,10 +203,11 @@ func (l *Lexer) NextToken() *token.Token {
 		if isLetter(l.ch) {
 			tok.Literal = l.readIdentifier()
 			tok.Flag = token.LookupIdent(tok.Literal)
+			tok.Span = token.Span{Start: start, End: l.position()}
 			return tok
 		} else if isDigit(l.ch) {
-			tok.Literal = l.readNumber()
-			tok.Flag = token.FlagInt
+			tok.Literal, tok.Flag = l.readNumber()
+			tok.Span = token.Span{Start: start, End: l.position()}
 			return tok
 		} else {
 			tok = token.New(token.FlagIllegal, l.ch)
@@ -131,9 +215,62 @@ func (l *Lexer) NextToken() *token.Token {
 	}
 
 	l.readChar()
+	tok.Span = token.Span{Start: start, End: l.position()}
+
+	if tok.Flag == token.FlagIllegal {
+		l.errorf(diag.CodeIllegalCharacter, tok.Span, "illegal character %q", tok.Literal)
+	}
 	return tok
 }
 
+// Diagnostics returns the problems found in the input scanned so far.
+func (l *Lexer) Diagnostics() []*diag.Diagnostic {
+	return l.diagnostics
+}
+
+func (l *Lexer) errorf(code diag.Code, span token.Span, format string, args ...interface{}) {
+	l.diagnostics = append(l.diagnostics, diag.Errorf(code, span, format, args...))
+}
+
+// readOperator reads an operator which may be followed by '=' to form its
+// compound assignment, like '+' and '+='.
+func (l *Lexer) readOperator(flag, assign token.Flag) *token.Token {
+	if l.peekChar() == '=' {
+		ch := l.ch
+		l.readChar()
+		return &token.Token{
+			Flag:    assign,
+			Literal: string(ch) + string(l.ch),
+		}
+	}
+	return token.New(flag, l.ch)
+}
+
+// readDouble reads an operator which may be made of the same character
+// twice, like '&' and '&&'.
+func (l *Lexer) readDouble(single, double token.Flag) *token.Token {
+	if l.peekChar() == l.ch {
+		ch := l.ch
+		l.readChar()
+		return &token.Token{
+			Flag:    double,
+			Literal: string(ch) + string(l.ch),
+		}
+	}
+	return token.New(single, l.ch)
+}
+
+// readDot reads either '.' or '...'.
+func (l *Lexer) readDot() *token.Token {
+	if l.peekChar() != '.' || l.rpos+1 >= len(l.input) || l.input[l.rpos+1] != '.' {
+		return token.New(token.FlagDot, l.ch)
+	}
+
+	l.readChar()
+	l.readChar()
+	return &token.Token{Flag: token.FlagEllipsis, Literal: "..."}
+}
+
 func (l *Lexer) readIdentifier() string {
 	pos := l.pos
 	for isLetter(l.ch) {
@@ -142,23 +279,66 @@ func (l *Lexer) readIdentifier() string {
 	return l.input[pos:l.pos]
 }
 
-func (l *Lexer) readString() string {
-	position := l.pos + 1
+// skipTrivia skips whitespace and comments, the comments are returned so
+// that they can be attached to the following token.
+func (l *Lexer) skipTrivia() []token.Comment {
+	var comments []token.Comment
+
 	for {
-		l.readChar()
-		if l.ch == '"' {
-			break
+		l.skipWhitespace()
+		if l.ch != '/' || (l.peekChar() != '/' && l.peekChar() != '*') {
+			return comments
 		}
+		comments = append(comments, l.readComment())
 	}
-	return l.input[position:l.pos]
 }
 
-func (l *Lexer) readNumber() string {
+func (l *Lexer) readComment() token.Comment {
+	start := l.position()
 	pos := l.pos
-	for isDigit(l.ch) {
+
+	if l.peekChar() == '/' {
+		for l.ch != '\n' && l.ch != 0 {
+			l.readChar()
+		}
+		return token.Comment{
+			Text: l.input[pos:l.pos],
+			Span: token.Span{Start: start, End: l.position()},
+		}
+	}
+
+	// skip the opening "/*"
+	l.readChar()
+	l.readChar()
+	opening := token.Span{Start: start, End: l.position()}
+
+	for l.ch != '*' || l.peekChar() != '/' {
+		if l.ch == 0 {
+			end := l.position()
+			d := diag.Errorf(diag.CodeUnterminatedComment, opening, "unterminated comment")
+			d.Fix = &diag.Fix{
+				Message:     "insert `*/`",
+				Span:        token.Span{Start: end, End: end},
+				Replacement: "*/",
+			}
+			l.diagnostics = append(l.diagnostics, d)
+
+			return token.Comment{
+				Text: l.input[pos:l.pos],
+				Span: token.Span{Start: start, End: end},
+			}
+		}
 		l.readChar()
 	}
-	return l.input[pos:l.pos]
+
+	// skip the closing "*/"
+	l.readChar()
+	l.readChar()
+
+	return token.Comment{
+		Text: l.input[pos:l.pos],
+		Span: token.Span{Start: start, End: l.position()},
+	}
 }
 
 func (l *Lexer) skipWhitespace() {
@@ -167,6 +347,17 @@ func (l *Lexer) skipWhitespace() {
 	}
 }
 
+// skipShebang skips the '#!' interpreter line which may lead a script so
+// that it can be executed directly on Unix-like systems.
+func (l *Lexer) skipShebang() {
+	if l.ch != '#' || l.peekChar() != '!' {
+		return
+	}
+	for l.ch != '\n' && l.ch != 0 {
+		l.readChar()
+	}
+}
+
 func isDigit(ch byte) bool {
 	return '0' <= ch && ch <= '9'
 }
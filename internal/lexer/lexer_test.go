@@ -21,6 +21,7 @@ package lexer_test
 import (
 	"testing"
 
+	"github.com/suenchunyu/snow-lang/internal/diag"
 	"github.com/suenchunyu/snow-lang/internal/lexer"
 	"github.com/suenchunyu/snow-lang/internal/token"
 )
@@ -34,7 +35,7 @@ let add = fn(x, y) {
 };
 
 let result = add(five, ten);
-!-/*5;
+!-/ *5;
 5 < 10 > 5;
 
 if (5 < 10) {
@@ -47,6 +48,7 @@ if (5 < 10) {
 "foobar"
 "foo bar"
 [1, 2];
+{"foo": "bar"}
 `
 
 	tests := []struct {
@@ -134,6 +136,659 @@ if (5 < 10) {
 		{token.FlagInt, "2"},
 		{token.FlagRBracket, "]"},
 		{token.FlagSemicolon, ";"},
+		{token.FlagLBrace, "{"},
+		{token.FlagString, "foo"},
+		{token.FlagColon, ":"},
+		{token.FlagString, "bar"},
+		{token.FlagRBrace, "}"},
+		{token.FlagEOF, ""},
+	}
+
+	l := lexer.New(input)
+
+	for idx, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Flag != tt.expectedFlag {
+			t.Fatalf("tests[%d] - wrong token type, expected = %q, got = %q", idx, tt.expectedFlag, tok.Flag)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - wrong literal, expected = %q, got = %q", idx, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestShebangLine(t *testing.T) {
+	input := "#!/usr/bin/env snow\nlet a = 1;"
+
+	tests := []struct {
+		expectedFlag    token.Flag
+		expectedLiteral string
+	}{
+		{token.FlagLet, "let"},
+		{token.FlagIdent, "a"},
+		{token.FlagAssign, "="},
+		{token.FlagInt, "1"},
+		{token.FlagSemicolon, ";"},
+		{token.FlagEOF, ""},
+	}
+
+	l := lexer.New(input)
+
+	for idx, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Flag != tt.expectedFlag {
+			t.Fatalf("tests[%d] - wrong token type, expected = %q, got = %q", idx, tt.expectedFlag, tok.Flag)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - wrong literal, expected = %q, got = %q", idx, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestTokenPositions(t *testing.T) {
+	input := `let five = 5;
+  five == 10;`
+
+	tests := []struct {
+		expectedLiteral string
+		expectedLine    int
+		expectedColumn  int
+		expectedOffset  int
+	}{
+		{"let", 1, 1, 0},
+		{"five", 1, 5, 4},
+		{"=", 1, 10, 9},
+		{"5", 1, 12, 11},
+		{";", 1, 13, 12},
+		{"five", 2, 3, 16},
+		{"==", 2, 8, 21},
+		{"10", 2, 11, 24},
+		{";", 2, 13, 26},
+		{"", 2, 14, 27},
+	}
+
+	l := lexer.NewFile("test.snow", input)
+
+	for idx, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - wrong literal, expected = %q, got = %q", idx, tt.expectedLiteral, tok.Literal)
+		}
+
+		pos := tok.Pos()
+		if pos.File != "test.snow" {
+			t.Fatalf("tests[%d] - wrong file, expected = %q, got = %q", idx, "test.snow", pos.File)
+		}
+
+		if pos.Line != tt.expectedLine || pos.Column != tt.expectedColumn || pos.Offset != tt.expectedOffset {
+			t.Fatalf("tests[%d] - wrong position, expected = %d:%d (offset %d), got = %d:%d (offset %d)",
+				idx, tt.expectedLine, tt.expectedColumn, tt.expectedOffset, pos.Line, pos.Column, pos.Offset)
+		}
+
+		if tok.Span.End.Offset-pos.Offset != len(tt.expectedLiteral) && tok.Literal != "" {
+			t.Fatalf("tests[%d] - wrong span length, expected = %d, got = %d",
+				idx, len(tt.expectedLiteral), tok.Span.End.Offset-pos.Offset)
+		}
+	}
+}
+
+func TestIllegalCharacterDiagnostic(t *testing.T) {
+	l := lexer.New("let a = @;")
+
+	for tok := l.NextToken(); tok.Flag != token.FlagEOF; tok = l.NextToken() {
+	}
+
+	diags := l.Diagnostics()
+	if len(diags) != 1 {
+		t.Fatalf("wrong number of diagnostics, expected = 1, got = %d", len(diags))
+	}
+
+	if diags[0].Code != diag.CodeIllegalCharacter {
+		t.Errorf("wrong code, expected = %q, got = %q", diag.CodeIllegalCharacter, diags[0].Code)
+	}
+
+	if diags[0].Error() != `1:9: illegal character "@"` {
+		t.Errorf("wrong diagnostic, got = %q", diags[0].Error())
+	}
+}
+
+func TestCompoundAssignment(t *testing.T) {
+	input := "a += 1; a -= 2; a *= 3; a /= 4; a = -5;"
+
+	tests := []struct {
+		expectedFlag    token.Flag
+		expectedLiteral string
+	}{
+		{token.FlagIdent, "a"},
+		{token.FlagPlusAssign, "+="},
+		{token.FlagInt, "1"},
+		{token.FlagSemicolon, ";"},
+		{token.FlagIdent, "a"},
+		{token.FlagMinusAssign, "-="},
+		{token.FlagInt, "2"},
+		{token.FlagSemicolon, ";"},
+		{token.FlagIdent, "a"},
+		{token.FlagAsteriskAssign, "*="},
+		{token.FlagInt, "3"},
+		{token.FlagSemicolon, ";"},
+		{token.FlagIdent, "a"},
+		{token.FlagSlashAssign, "/="},
+		{token.FlagInt, "4"},
+		{token.FlagSemicolon, ";"},
+		{token.FlagIdent, "a"},
+		{token.FlagAssign, "="},
+		{token.FlagMinus, "-"},
+		{token.FlagInt, "5"},
+		{token.FlagSemicolon, ";"},
+		{token.FlagEOF, ""},
+	}
+
+	l := lexer.New(input)
+
+	for idx, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Flag != tt.expectedFlag {
+			t.Fatalf("tests[%d] - wrong token type, expected = %q, got = %q", idx, tt.expectedFlag, tok.Flag)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - wrong literal, expected = %q, got = %q", idx, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestLoopKeywords(t *testing.T) {
+	input := "for while in break continue"
+
+	expected := []token.Flag{
+		token.FlagFor,
+		token.FlagWhile,
+		token.FlagIn,
+		token.FlagBreak,
+		token.FlagContinue,
+		token.FlagEOF,
+	}
+
+	l := lexer.New(input)
+
+	for idx, flag := range expected {
+		tok := l.NextToken()
+
+		if tok.Flag != flag {
+			t.Fatalf("tests[%d] - wrong token type, expected = %q, got = %q", idx, flag, tok.Flag)
+		}
+	}
+}
+
+func TestComparisonAndLogicalOperators(t *testing.T) {
+	input := "a <= b >= c && d || e % f; a %= 2; a & b"
+
+	tests := []struct {
+		expectedFlag    token.Flag
+		expectedLiteral string
+	}{
+		{token.FlagIdent, "a"},
+		{token.FlagLessEqual, "<="},
+		{token.FlagIdent, "b"},
+		{token.FlagGreaterEqual, ">="},
+		{token.FlagIdent, "c"},
+		{token.FlagAnd, "&&"},
+		{token.FlagIdent, "d"},
+		{token.FlagOr, "||"},
+		{token.FlagIdent, "e"},
+		{token.FlagPercent, "%"},
+		{token.FlagIdent, "f"},
+		{token.FlagSemicolon, ";"},
+		{token.FlagIdent, "a"},
+		{token.FlagPercentAssign, "%="},
+		{token.FlagInt, "2"},
+		{token.FlagSemicolon, ";"},
+		{token.FlagIdent, "a"},
+		{token.FlagAmpersand, "&"},
+		{token.FlagIdent, "b"},
+		{token.FlagEOF, ""},
+	}
+
+	l := lexer.New(input)
+
+	for idx, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Flag != tt.expectedFlag {
+			t.Fatalf("tests[%d] - wrong token type, expected = %q, got = %q", idx, tt.expectedFlag, tok.Flag)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - wrong literal, expected = %q, got = %q", idx, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestComments(t *testing.T) {
+	input := `// leading comment
+let a = 1; // trailing
+/* block
+   comment */ a /**/ / 2;
+`
+
+	tests := []struct {
+		expectedFlag    token.Flag
+		expectedLiteral string
+		leading         []string
+	}{
+		{token.FlagLet, "let", []string{"// leading comment"}},
+		{token.FlagIdent, "a", nil},
+		{token.FlagAssign, "=", nil},
+		{token.FlagInt, "1", nil},
+		{token.FlagSemicolon, ";", nil},
+		{token.FlagIdent, "a", []string{"// trailing", "/* block\n   comment */"}},
+		{token.FlagSlash, "/", []string{"/**/"}},
+		{token.FlagInt, "2", nil},
+		{token.FlagSemicolon, ";", nil},
+		{token.FlagEOF, "", nil},
+	}
+
+	l := lexer.New(input)
+
+	for idx, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Flag != tt.expectedFlag {
+			t.Fatalf("tests[%d] - wrong token type, expected = %q, got = %q", idx, tt.expectedFlag, tok.Flag)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - wrong literal, expected = %q, got = %q", idx, tt.expectedLiteral, tok.Literal)
+		}
+
+		if len(tok.Leading) != len(tt.leading) {
+			t.Fatalf("tests[%d] - wrong number of comments, expected = %d, got = %d", idx, len(tt.leading), len(tok.Leading))
+		}
+
+		for i, comment := range tok.Leading {
+			if comment.Text != tt.leading[i] {
+				t.Errorf("tests[%d] - wrong comment, expected = %q, got = %q", idx, tt.leading[i], comment.Text)
+			}
+		}
+	}
+
+	if len(l.Diagnostics()) != 0 {
+		t.Errorf("unexpected diagnostics: %v", l.Diagnostics())
+	}
+}
+
+func TestCommentPositions(t *testing.T) {
+	l := lexer.New("a\n  /* x */ b")
+
+	l.NextToken()
+	tok := l.NextToken()
+
+	if len(tok.Leading) != 1 {
+		t.Fatalf("wrong number of comments, expected = 1, got = %d", len(tok.Leading))
+	}
+
+	comment := tok.Leading[0]
+	if !comment.IsBlock() {
+		t.Errorf("comment is not a block comment")
+	}
+
+	if comment.Span.Start.String() != "2:3" || comment.Span.End.String() != "2:10" {
+		t.Errorf("wrong comment span, got = %s-%s", comment.Span.Start, comment.Span.End)
+	}
+
+	if tok.Pos().String() != "2:11" {
+		t.Errorf("wrong token position, got = %s", tok.Pos())
+	}
+}
+
+func TestUnterminatedComment(t *testing.T) {
+	l := lexer.New("let a = 1;\n/* never closed\nlet b = 2;")
+
+	for tok := l.NextToken(); tok.Flag != token.FlagEOF; tok = l.NextToken() {
+	}
+
+	diags := l.Diagnostics()
+	if len(diags) != 1 {
+		t.Fatalf("wrong number of diagnostics, expected = 1, got = %d", len(diags))
+	}
+
+	if diags[0].Code != diag.CodeUnterminatedComment {
+		t.Errorf("wrong code, expected = %q, got = %q", diag.CodeUnterminatedComment, diags[0].Code)
+	}
+
+	if diags[0].Error() != "2:1: unterminated comment" {
+		t.Errorf("wrong diagnostic, got = %q", diags[0].Error())
+	}
+
+	if diags[0].Fix == nil || diags[0].Fix.Replacement != "*/" {
+		t.Errorf("expected a fix inserting */, got = %+v", diags[0].Fix)
+	}
+}
+
+func TestMalformedNumbers(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"0x", "1:1: hexadecimal literal has no digits"},
+		{"0b", "1:1: binary literal has no digits"},
+		{"0o_", "1:1: octal literal has no digits"},
+		{"0b1021", "1:5: invalid digit '2' in binary literal"},
+		{"0o78", "1:4: invalid digit '8' in octal literal"},
+		{"0129", "1:4: invalid digit '9' in octal literal"},
+		{"1__0", "1:2: '_' must separate successive digits"},
+		{"1_", "1:2: '_' must separate successive digits"},
+		{"let a = 10_", "1:11: '_' must separate successive digits"},
+		{"1_.5", "1:2: '_' must separate successive digits"},
+		{"0x_", "1:1: hexadecimal literal has no digits"},
+		{"0xF__F", "1:4: '_' must separate successive digits"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+
+		var illegal bool
+		for tok := l.NextToken(); tok.Flag != token.FlagEOF; tok = l.NextToken() {
+			illegal = illegal || tok.Flag == token.FlagIllegal
+		}
+
+		if !illegal {
+			t.Errorf("%s: no illegal token", tt.input)
+		}
+
+		diags := l.Diagnostics()
+		if len(diags) != 1 {
+			t.Errorf("%s: wrong number of diagnostics, expected = 1, got = %d", tt.input, len(diags))
+			continue
+		}
+
+		if diags[0].Code != diag.CodeMalformedNumber {
+			t.Errorf("%s: wrong code, expected = %q, got = %q", tt.input, diag.CodeMalformedNumber, diags[0].Code)
+		}
+
+		if diags[0].Error() != tt.expected {
+			t.Errorf("%s: wrong diagnostic, expected = %q, got = %q", tt.input, tt.expected, diags[0].Error())
+		}
+	}
+}
+
+func TestIntegerLiteralForms(t *testing.T) {
+	input := "0xFF 0o17 0b1010 1_000 0x_ff 1_000.5 007 0"
+
+	expected := []string{"0xFF", "0o17", "0b1010", "1_000", "0x_ff", "1_000.5", "007", "0"}
+
+	l := lexer.New(input)
+
+	for idx, literal := range expected {
+		tok := l.NextToken()
+
+		if tok.Flag != token.FlagInt && tok.Flag != token.FlagFloat {
+			t.Fatalf("tests[%d] - wrong token type, got = %q (%q)", idx, tok.Flag, tok.Literal)
+		}
+
+		if tok.Literal != literal {
+			t.Fatalf("tests[%d] - wrong literal, expected = %q, got = %q", idx, literal, tok.Literal)
+		}
+	}
+
+	if diags := l.Diagnostics(); len(diags) != 0 {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+}
+
+func TestBitwiseOperators(t *testing.T) {
+	input := "a & b | c ^ ~d << 2 >> 1 && e || f < g > h"
+
+	expected := []token.Flag{
+		token.FlagIdent,
+		token.FlagAmpersand,
+		token.FlagIdent,
+		token.FlagPipe,
+		token.FlagIdent,
+		token.FlagCaret,
+		token.FlagTilde,
+		token.FlagIdent,
+		token.FlagShiftLeft,
+		token.FlagInt,
+		token.FlagShiftRight,
+		token.FlagInt,
+		token.FlagAnd,
+		token.FlagIdent,
+		token.FlagOr,
+		token.FlagIdent,
+		token.FlagLessThan,
+		token.FlagIdent,
+		token.FlagGreaterThan,
+		token.FlagIdent,
+		token.FlagEOF,
+	}
+
+	l := lexer.New(input)
+
+	for idx, flag := range expected {
+		tok := l.NextToken()
+
+		if tok.Flag != flag {
+			t.Fatalf("tests[%d] - wrong token type, expected = %q, got = %q", idx, flag, tok.Flag)
+		}
+	}
+}
+
+func TestStringEscapes(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`"plain"`, "plain"},
+		{`"a\"b"`, `a"b`},
+		{`"line\nbreak"`, "line\nbreak"},
+		{`"\t\r\\"`, "\t\r\\"},
+		{`"\u{41}\u{1F600}"`, "A\U0001F600"},
+		{`"snow ❄"`, "snow ❄"},
+		{"`raw \\n`", `raw \n`},
+		{"`multi\r\nline`", "multi\nline"},
+		{"``", ""},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		tok := l.NextToken()
+
+		if tok.Flag != token.FlagString {
+			t.Errorf("%s: wrong token type, expected = %q, got = %q", tt.input, token.FlagString, tok.Flag)
+			continue
+		}
+
+		if tok.Literal != tt.expected {
+			t.Errorf("%s: wrong value, expected = %q, got = %q", tt.input, tt.expected, tok.Literal)
+		}
+
+		if next := l.NextToken(); next.Flag != token.FlagEOF {
+			t.Errorf("%s: expected EOF after the string, got = %q", tt.input, next.Flag)
+		}
+
+		if diags := l.Diagnostics(); len(diags) != 0 {
+			t.Errorf("%s: unexpected diagnostics: %v", tt.input, diags)
+		}
+	}
+}
+
+func TestInvalidEscapes(t *testing.T) {
+	tests := []struct {
+		input    string
+		value    string
+		expected string
+	}{
+		{`"a\qb"`, "aqb", "1:3: unknown escape sequence \\q"},
+		{`"\u41"`, "41", "1:2: invalid unicode escape, expected \\u{...}"},
+		{`"\u{}"`, "", "1:2: invalid unicode escape, expected \\u{...}"},
+		{`"\u{1234567}"`, "", "1:2: invalid unicode escape, expected \\u{...}"},
+		{`"\u{D800}"`, "", "1:2: invalid unicode code point U+D800"},
+		{`"\u{110000}"`, "", "1:2: invalid unicode code point U+110000"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		tok := l.NextToken()
+
+		if tok.Flag != token.FlagString || tok.Literal != tt.value {
+			t.Errorf("%s: wrong token, got = %q %q", tt.input, tok.Flag, tok.Literal)
+		}
+
+		diags := l.Diagnostics()
+		if len(diags) != 1 {
+			t.Errorf("%s: wrong number of diagnostics, expected = 1, got = %d", tt.input, len(diags))
+			continue
+		}
+
+		if diags[0].Code != diag.CodeInvalidEscape || diags[0].Error() != tt.expected {
+			t.Errorf("%s: wrong diagnostic, expected = %q, got = %s %q", tt.input, tt.expected, diags[0].Code, diags[0].Error())
+		}
+	}
+}
+
+func TestUnterminatedString(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		fix      string
+	}{
+		{"let a = \"abc\nlet b = 2;", "1:9: unterminated string literal", `"`},
+		{"let a = \"abc\\", "1:9: unterminated string literal", `"`},
+		{"let a = `abc\n\nlet b = 2;", "1:9: unterminated raw string literal", "`"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+
+		var flags []token.Flag
+		for tok := l.NextToken(); tok.Flag != token.FlagEOF; tok = l.NextToken() {
+			flags = append(flags, tok.Flag)
+		}
+
+		if flags[3] != token.FlagIllegal {
+			t.Errorf("%q: the string isn't an illegal token, got = %q", tt.input, flags[3])
+		}
+
+		diags := l.Diagnostics()
+		if len(diags) != 1 {
+			t.Errorf("%q: wrong number of diagnostics, expected = 1, got = %d", tt.input, len(diags))
+			continue
+		}
+
+		if diags[0].Code != diag.CodeUnterminatedString || diags[0].Error() != tt.expected {
+			t.Errorf("%q: wrong diagnostic, expected = %q, got = %s %q", tt.input, tt.expected, diags[0].Code, diags[0].Error())
+		}
+
+		if diags[0].Fix == nil || diags[0].Fix.Replacement != tt.fix {
+			t.Errorf("%q: expected a fix inserting %s, got = %+v", tt.input, tt.fix, diags[0].Fix)
+		}
+	}
+
+	// lexing resumes on the next line.
+	l := lexer.New("\"abc\nlet b = 2;")
+	l.NextToken()
+	if tok := l.NextToken(); tok.Flag != token.FlagLet {
+		t.Errorf("expected let after the unterminated string, got = %q", tok.Flag)
+	}
+}
+
+func TestTemplateStrings(t *testing.T) {
+	input := `"a ${b + {"c": 1}["c"]} d ${"e${f}"} \${g}"`
+
+	tests := []struct {
+		expectedFlag    token.Flag
+		expectedLiteral string
+	}{
+		{token.FlagTemplateStart, "a "},
+		{token.FlagIdent, "b"},
+		{token.FlagPlus, "+"},
+		{token.FlagLBrace, "{"},
+		{token.FlagString, "c"},
+		{token.FlagColon, ":"},
+		{token.FlagInt, "1"},
+		{token.FlagRBrace, "}"},
+		{token.FlagLBracket, "["},
+		{token.FlagString, "c"},
+		{token.FlagRBracket, "]"},
+		{token.FlagTemplateMiddle, " d "},
+		{token.FlagTemplateStart, "e"},
+		{token.FlagIdent, "f"},
+		{token.FlagTemplateEnd, ""},
+		{token.FlagTemplateEnd, " ${g}"},
+		{token.FlagEOF, ""},
+	}
+
+	l := lexer.New(input)
+
+	for idx, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Flag != tt.expectedFlag {
+			t.Fatalf("tests[%d] - wrong token type, expected = %q, got = %q", idx, tt.expectedFlag, tok.Flag)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - wrong literal, expected = %q, got = %q", idx, tt.expectedLiteral, tok.Literal)
+		}
+	}
+
+	if diags := l.Diagnostics(); len(diags) != 0 {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+}
+
+func TestEllipsis(t *testing.T) {
+	input := "fn(...rest) .."
+
+	expected := []token.Flag{
+		token.FlagFunction,
+		token.FlagLParen,
+		token.FlagEllipsis,
+		token.FlagIdent,
+		token.FlagRParen,
+		token.FlagDot,
+		token.FlagDot,
+		token.FlagEOF,
+	}
+
+	l := lexer.New(input)
+
+	for idx, flag := range expected {
+		tok := l.NextToken()
+
+		if tok.Flag != flag {
+			t.Fatalf("tests[%d] - wrong token type, expected = %q, got = %q", idx, flag, tok.Flag)
+		}
+	}
+}
+
+func TestNumbers(t *testing.T) {
+	input := "5 3.14 1e3 2.5E-3 7e+2 1.foo 2e 3.e"
+
+	tests := []struct {
+		expectedFlag    token.Flag
+		expectedLiteral string
+	}{
+		{token.FlagInt, "5"},
+		{token.FlagFloat, "3.14"},
+		{token.FlagFloat, "1e3"},
+		{token.FlagFloat, "2.5E-3"},
+		{token.FlagFloat, "7e+2"},
+		{token.FlagInt, "1"},
+		{token.FlagDot, "."},
+		{token.FlagIdent, "foo"},
+		{token.FlagInt, "2"},
+		{token.FlagIdent, "e"},
+		{token.FlagInt, "3"},
+		{token.FlagDot, "."},
+		{token.FlagIdent, "e"},
 		{token.FlagEOF, ""},
 	}
 
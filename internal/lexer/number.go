@@ -0,0 +1,224 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package lexer
+
+import (
+	"github.com/suenchunyu/snow-lang/internal/diag"
+	"github.com/suenchunyu/snow-lang/internal/token"
+)
+
+// readNumber reads an integer or a float literal. Integers may have a base
+// prefix `0x`, `0o` or `0b`, floats have a fraction `1.5`, an exponent
+// `15e-1` or both, and digits may be separated by '_' like `1_000`. A dot
+// which isn't followed by a digit isn't part of the number, so `1.method`
+// still lexes as a property access.
+//
+// Malformed literals are reported and returned as illegal tokens, so that
+// the parser doesn't report them again.
+func (l *Lexer) readNumber() (string, token.Flag) {
+	start := l.position()
+	flag := token.FlagInt
+
+	base := 10
+	if l.ch == '0' {
+		base = basePrefix(l.peekChar())
+		if base != 10 {
+			l.readChar()
+			l.readChar()
+		}
+	}
+
+	digits, invalid := l.readDigits(base)
+	if invalid >= 0 {
+		invalid -= start.Offset
+	}
+
+	if base == 10 {
+		if l.ch == '.' && isDigit(l.peekChar()) {
+			flag = token.FlagFloat
+			l.readChar()
+			l.readDigits(base)
+		}
+
+		if (l.ch == 'e' || l.ch == 'E') && l.exponentFollows() {
+			flag = token.FlagFloat
+			l.readChar()
+			if l.ch == '+' || l.ch == '-' {
+				l.readChar()
+			}
+			l.readDigits(base)
+		}
+	}
+
+	literal := l.input[start.Offset:l.pos]
+	span := token.Span{Start: start, End: l.position()}
+
+	// a leading zero makes an integer octal, like in Go.
+	if base == 10 && flag == token.FlagInt && len(literal) > 1 && literal[0] == '0' {
+		base = 8
+		_, invalid = digitsOf(literal, base)
+	}
+
+	switch {
+	case digits == 0:
+		l.errorf(diag.CodeMalformedNumber, span, "%s literal has no digits", baseName(base))
+	case invalid >= 0:
+		l.errorf(diag.CodeMalformedNumber, charSpan(start, invalid), "invalid digit %q in %s literal", literal[invalid], baseName(base))
+	default:
+		sep := invalidSeparator(literal, base)
+		if sep < 0 {
+			return literal, flag
+		}
+		l.errorf(diag.CodeMalformedNumber, charSpan(start, sep), "'_' must separate successive digits")
+	}
+
+	return literal, token.FlagIllegal
+}
+
+// readDigits reads the digits of a number and the '_' separating them, it
+// returns the number of digits and the offset in the input of the first
+// digit which is invalid in base, or -1. Decimal digits are read for every
+// base so that a misplaced one is reported instead of starting a new token.
+func (l *Lexer) readDigits(base int) (digits, invalid int) {
+	start := l.pos
+	for isDigit(l.ch) || l.ch == '_' || (base == 16 && isHexDigit(l.ch)) {
+		l.readChar()
+	}
+
+	digits, invalid = digitsOf(l.input[start:l.pos], base)
+	if invalid >= 0 {
+		invalid += start
+	}
+	return digits, invalid
+}
+
+// exponentFollows reports whether the 'e' at the current position starts
+// the exponent of a float, it must be followed by digits with an optional
+// sign.
+func (l *Lexer) exponentFollows() bool {
+	next := l.peekChar()
+	if (next == '+' || next == '-') && l.rpos+1 < len(l.input) {
+		next = l.input[l.rpos+1]
+	}
+	return isDigit(next)
+}
+
+// digitsOf counts the digits of s, skipping separators, and returns the
+// offset of the first one which is invalid in base, or -1.
+func digitsOf(s string, base int) (digits, invalid int) {
+	invalid = -1
+	for idx := 0; idx < len(s); idx++ {
+		ch := s[idx]
+		if ch == '_' {
+			continue
+		}
+		if digitValue(ch) >= base && invalid < 0 {
+			invalid = idx
+		}
+		digits++
+	}
+	return digits, invalid
+}
+
+// invalidSeparator returns the offset of the first '_' of lit which doesn't
+// separate two digits, or -1. A separator may also follow the base prefix,
+// like `0x_ff`.
+func invalidSeparator(lit string, base int) int {
+	begin := 0
+	if base != 10 && len(lit) > 1 && basePrefix(lit[1]) == base {
+		begin = 2
+	}
+
+	for idx := begin; idx < len(lit); idx++ {
+		if lit[idx] != '_' {
+			continue
+		}
+
+		after := idx+1 < len(lit) && isDigitIn(lit[idx+1], base)
+		before := (idx == begin && begin > 0) || (idx > begin && isDigitIn(lit[idx-1], base))
+		if !before || !after {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// charSpan returns the span of the character at offset of a literal starting
+// at start, numbers don't span multiple lines.
+func charSpan(start token.Pos, offset int) token.Span {
+	pos := start
+	pos.Offset += offset
+	pos.Column += offset
+
+	end := pos
+	end.Offset++
+	end.Column++
+
+	return token.Span{Start: pos, End: end}
+}
+
+func basePrefix(ch byte) int {
+	switch ch {
+	case 'x', 'X':
+		return 16
+	case 'o', 'O':
+		return 8
+	case 'b', 'B':
+		return 2
+	default:
+		return 10
+	}
+}
+
+func baseName(base int) string {
+	switch base {
+	case 16:
+		return "hexadecimal"
+	case 8:
+		return "octal"
+	case 2:
+		return "binary"
+	default:
+		return "decimal"
+	}
+}
+
+// isDigitIn reports whether ch is a digit of a literal in base, decimal
+// digits are always part of the literal even if they're invalid in base.
+func isDigitIn(ch byte, base int) bool {
+	return isDigit(ch) || (base == 16 && isHexDigit(ch))
+}
+
+func digitValue(ch byte) int {
+	switch {
+	case isDigit(ch):
+		return int(ch - '0')
+	case 'a' <= ch && ch <= 'f':
+		return int(ch-'a') + 10
+	case 'A' <= ch && ch <= 'F':
+		return int(ch-'A') + 10
+	default:
+		return 16
+	}
+}
+
+func isHexDigit(ch byte) bool {
+	return isDigit(ch) || 'a' <= ch && ch <= 'f' || 'A' <= ch && ch <= 'F'
+}
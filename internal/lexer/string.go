@@ -0,0 +1,191 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package lexer
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/suenchunyu/snow-lang/internal/diag"
+	"github.com/suenchunyu/snow-lang/internal/token"
+)
+
+// readString reads a string literal delimited by '"' and returns its value
+// with the escape sequences decoded. The literal must end on the line it
+// starts on, an unterminated one is reported and returned as an illegal
+// token.
+//
+// A string embedding expressions `"a ${b} c"` is a template, it's split into
+// a TemplateStart token with the text up to the first `${`, the tokens of the
+// expression, and a TemplateMiddle or TemplateEnd token starting with the `}`
+// which closes the expression.
+func (l *Lexer) readString() (string, token.Flag) {
+	start := l.position()
+	l.readChar()
+	return l.readStringText(start, token.FlagString, token.FlagTemplateStart)
+}
+
+// readTemplateText reads the text of a template following the `}` which
+// closes one of its expressions.
+func (l *Lexer) readTemplateText() (string, token.Flag) {
+	start := l.position()
+	l.readChar()
+	return l.readStringText(start, token.FlagTemplateEnd, token.FlagTemplateMiddle)
+}
+
+// readStringText reads the characters of a string up to its closing '"', the
+// value is returned as a closed token then, or up to a `${` starting an
+// embedded expression, the value is returned as an open token then.
+func (l *Lexer) readStringText(start token.Pos, closed, open token.Flag) (string, token.Flag) {
+	var out strings.Builder
+
+	for {
+		switch {
+		case l.ch == '"':
+			l.readChar()
+			return out.String(), closed
+		case l.ch == '$' && l.peekChar() == '{':
+			l.readChar()
+			l.readChar()
+			l.templates = append(l.templates, 0)
+			return out.String(), open
+		case l.ch == '\n' || l.atEOF():
+			l.unterminated(start, `"`, "unterminated string literal")
+			return out.String(), token.FlagIllegal
+		case l.ch == '\\':
+			l.readEscape(&out)
+		default:
+			out.WriteByte(l.ch)
+			l.readChar()
+		}
+	}
+}
+
+// readRawString reads a string literal delimited by '`', it may span
+// multiple lines and has no escape sequences. Carriage returns are dropped
+// so that the value doesn't depend on the line endings of the file.
+func (l *Lexer) readRawString() (string, token.Flag) {
+	start := l.position()
+	var out strings.Builder
+
+	l.readChar()
+	for l.ch != '`' {
+		if l.atEOF() {
+			l.unterminated(start, "`", "unterminated raw string literal")
+			return out.String(), token.FlagIllegal
+		}
+		if l.ch != '\r' {
+			out.WriteByte(l.ch)
+		}
+		l.readChar()
+	}
+
+	l.readChar()
+	return out.String(), token.FlagString
+}
+
+// readEscape decodes the escape sequence starting at the current '\\' into
+// out. Unknown escapes are reported and kept as the escaped character.
+func (l *Lexer) readEscape(out *strings.Builder) {
+	start := l.position()
+	l.readChar()
+
+	switch l.ch {
+	case 'n':
+		out.WriteByte('\n')
+	case 't':
+		out.WriteByte('\t')
+	case 'r':
+		out.WriteByte('\r')
+	case '\\', '"', '$':
+		out.WriteByte(l.ch)
+	case 'u':
+		l.readUnicodeEscape(out, start)
+		return
+	default:
+		// a line break or the end of input terminates the literal.
+		if l.ch == '\n' || l.atEOF() {
+			return
+		}
+		l.errorf(diag.CodeInvalidEscape, l.spanFrom(start, 1), "unknown escape sequence \\%c", l.ch)
+		out.WriteByte(l.ch)
+	}
+
+	l.readChar()
+}
+
+// readUnicodeEscape decodes `\u{...}`, the code point of a character as 1 to
+// 6 hexadecimal digits. start is the position of the '\\'.
+func (l *Lexer) readUnicodeEscape(out *strings.Builder, start token.Pos) {
+	l.readChar()
+	if l.ch != '{' {
+		l.errorf(diag.CodeInvalidEscape, l.spanFrom(start, 0), "invalid unicode escape, expected \\u{...}")
+		return
+	}
+	l.readChar()
+
+	begin := l.pos
+	for isHexDigit(l.ch) {
+		l.readChar()
+	}
+	digits := l.input[begin:l.pos]
+
+	if l.ch != '}' || len(digits) == 0 || len(digits) > 6 {
+		if l.ch == '}' {
+			l.readChar()
+		}
+		l.errorf(diag.CodeInvalidEscape, l.spanFrom(start, 0), "invalid unicode escape, expected \\u{...}")
+		return
+	}
+	l.readChar()
+
+	value, _ := strconv.ParseUint(digits, 16, 32)
+	if !utf8.ValidRune(rune(value)) {
+		l.errorf(diag.CodeInvalidEscape, l.spanFrom(start, 0), "invalid unicode code point U+%04X", value)
+		return
+	}
+	out.WriteRune(rune(value))
+}
+
+// unterminated reports the literal opened at start which is missing its
+// closing delimiter, the fix inserts it at the current position.
+func (l *Lexer) unterminated(start token.Pos, delimiter, message string) {
+	end := l.position()
+	d := diag.Errorf(diag.CodeUnterminatedString, charSpan(start, 0), message)
+	d.Fix = &diag.Fix{
+		Message:     "insert `" + delimiter + "`",
+		Span:        token.Span{Start: end, End: end},
+		Replacement: delimiter,
+	}
+	l.diagnostics = append(l.diagnostics, d)
+}
+
+// spanFrom returns the span from start to the current position, extended by
+// the given number of characters.
+func (l *Lexer) spanFrom(start token.Pos, extra int) token.Span {
+	end := l.position()
+	end.Offset += extra
+	end.Column += extra
+	return token.Span{Start: start, End: end}
+}
+
+func (l *Lexer) atEOF() bool {
+	return l.pos >= len(l.input)
+}
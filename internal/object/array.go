@@ -0,0 +1,75 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package object
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+type Array struct {
+	Elements []Object
+}
+
+func (a *Array) Type() Type {
+	return TypeArray
+}
+
+func (a *Array) Inspect() string {
+	return a.inspect(make(map[Object]bool))
+}
+
+// inspect formats the array, seen holds the containers being formatted by
+// the callers so that an array holding itself prints as [...].
+func (a *Array) inspect(seen map[Object]bool) string {
+	if seen[a] {
+		return "[...]"
+	}
+	seen[a] = true
+	defer delete(seen, a)
+
+	var out bytes.Buffer
+
+	elements := make([]string, 0)
+	for _, ele := range a.Elements {
+		elements = append(elements, inspectElement(ele, seen))
+	}
+
+	out.WriteString("[")
+	out.WriteString(strings.Join(elements, ", "))
+	out.WriteString("]")
+
+	return out.String()
+}
+
+// inspectElement inspects an object nested in a container, strings are
+// quoted so that they can be told apart from other values.
+func inspectElement(obj Object, seen map[Object]bool) string {
+	switch obj := obj.(type) {
+	case *String:
+		return strconv.Quote(obj.Value)
+	case *Array:
+		return obj.inspect(seen)
+	case *Hash:
+		return obj.inspect(seen)
+	default:
+		return obj.Inspect()
+	}
+}
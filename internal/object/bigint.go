@@ -0,0 +1,51 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package object
+
+import "math/big"
+
+// BigInt is an integer which doesn't fit into an int64. Integer operations
+// produce one when their result overflows, and an Integer again once the
+// result fits, so the same value is never represented by both types.
+type BigInt struct {
+	Value *big.Int
+}
+
+// NewInteger returns v as an Integer if it fits into an int64, as a BigInt
+// otherwise. v must not be modified afterwards.
+func NewInteger(v *big.Int) Object {
+	if v.IsInt64() {
+		return &Integer{Value: v.Int64()}
+	}
+	return &BigInt{Value: v}
+}
+
+func (b *BigInt) Type() Type {
+	return TypeBigInt
+}
+
+func (b *BigInt) Inspect() string {
+	return b.Value.String()
+}
+
+// HashKey is an integer key, it can't collide with the key of an Integer as
+// the value of a BigInt is out of their range.
+func (b *BigInt) HashKey() HashKey {
+	return HashKey{Type: TypeInteger, Text: b.Value.String()}
+}
@@ -0,0 +1,64 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package object
+
+import "github.com/suenchunyu/snow-lang/internal/token"
+
+// Break signals the enclosing loop to stop, it's unwound through block
+// statements the same way as a ReturnValue.
+type Break struct {
+	Pos token.Pos
+}
+
+func (b *Break) Type() Type {
+	return TypeBreak
+}
+
+func (b *Break) Inspect() string {
+	return "break"
+}
+
+// Continue signals the enclosing loop to skip to its next iteration.
+type Continue struct {
+	Pos token.Pos
+}
+
+func (c *Continue) Type() Type {
+	return TypeContinue
+}
+
+func (c *Continue) Inspect() string {
+	return "continue"
+}
+
+// TailCall is the result of a call in tail position, the function making it
+// returns it to the caller which performs the call in place of its own frame.
+type TailCall struct {
+	Function  *Function
+	Arguments []Object
+	Pos       token.Pos
+}
+
+func (tc *TailCall) Type() Type {
+	return TypeTailCall
+}
+
+func (tc *TailCall) Inspect() string {
+	return "tail call"
+}
@@ -18,10 +18,30 @@
 
 package object
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/suenchunyu/snow-lang/internal/diag"
+	"github.com/suenchunyu/snow-lang/internal/token"
+)
 
 type Error struct {
+	Code    diag.Code
 	Message string
+	Pos     token.Pos
+	// Trace is the call stack at the time the error was raised, innermost
+	// call first.
+	Trace []Frame
+}
+
+// Frame is an entry of the call stack, the call of Function at Pos.
+type Frame struct {
+	Function string
+	Pos      token.Pos
+}
+
+func (f Frame) String() string {
+	return fmt.Sprintf("at %s (%s)", f.Function, f.Pos)
 }
 
 func (e *Error) Type() Type {
@@ -29,5 +49,24 @@ func (e *Error) Type() Type {
 }
 
 func (e *Error) Inspect() string {
+	if e.Pos.IsValid() {
+		return fmt.Sprintf("ERROR: %s: %s", e.Pos, e.Message)
+	}
 	return fmt.Sprintf("ERROR: %s", e.Message)
 }
+
+// Diagnostic converts the runtime error into a diagnostic for reporting.
+func (e *Error) Diagnostic() *diag.Diagnostic {
+	code := e.Code
+	if code == "" {
+		code = diag.CodeRuntime
+	}
+	d := diag.Errorf(code, token.Span{Start: e.Pos, End: e.Pos}, "%s", e.Message)
+	for _, frame := range e.Trace {
+		d.Trace = append(d.Trace, diag.Note{
+			Message: frame.Function,
+			Span:    token.Span{Start: frame.Pos, End: frame.Pos},
+		})
+	}
+	return d
+}
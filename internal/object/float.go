@@ -0,0 +1,66 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package object
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+type Float struct {
+	Value float64
+}
+
+func (f *Float) Type() Type {
+	return TypeFloat
+}
+
+// Inspect formats the shortest representation which reads back as the same
+// value, whole numbers keep a fraction `1.0` to tell them from integers. Like
+// JavaScript, exponents are only used for magnitudes below 1e-6 or from 1e21
+// up so that amounts like 1000000.0 print in full.
+func (f *Float) Inspect() string {
+	format := byte('g')
+	if abs := math.Abs(f.Value); abs == 0 || abs >= 1e-6 && abs < 1e21 {
+		format = 'f'
+	}
+
+	s := strconv.FormatFloat(f.Value, format, -1, 64)
+	if strings.ContainsAny(s, ".eIN") {
+		return s
+	}
+	return s + ".0"
+}
+
+// HashKey of a whole number is the key of the equal integer, as 1 == 1.0
+// the two must find the same pair.
+func (f *Float) HashKey() HashKey {
+	value := f.Value
+	if value == math.Trunc(value) && !math.IsInf(value, 0) {
+		// -0.0 is whole too, it shares the key of 0.
+		if value >= math.MinInt64 && value < -math.MinInt64 {
+			return (&Integer{Value: int64(value)}).HashKey()
+		}
+		i, _ := big.NewFloat(value).Int(nil)
+		return (&BigInt{Value: i}).HashKey()
+	}
+	return HashKey{Type: f.Type(), Value: math.Float64bits(value)}
+}
@@ -20,15 +20,19 @@ package object
 
 import (
 	"bytes"
-	"strings"
 
 	"github.com/suenchunyu/snow-lang/internal/ast"
 )
 
 type Function struct {
+	// Name is empty for anonymous functions.
+	Name       string
 	Env        *Environment
 	Body       *ast.BlockStatement
 	Parameters []*ast.Identifier
+	// Defaults and Rest are taken from the function literal, see there.
+	Defaults []ast.Expression
+	Rest     *ast.Identifier
 }
 
 func (f *Function) Type() Type {
@@ -38,14 +42,13 @@ func (f *Function) Type() Type {
 func (f *Function) Inspect() string {
 	var out bytes.Buffer
 
-	params := make([]string, 0)
-	for _, param := range f.Parameters {
-		params = append(params, param.String())
-	}
-
 	out.WriteString("fn")
+	if f.Name != "" {
+		out.WriteString(" ")
+		out.WriteString(f.Name)
+	}
 	out.WriteString("(")
-	out.WriteString(strings.Join(params, ","))
+	out.WriteString(ast.FormatParameters(f.Parameters, f.Defaults, f.Rest))
 	out.WriteString(") ")
 	out.WriteString(f.Body.String())
 
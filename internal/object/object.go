@@ -29,6 +29,14 @@ const (
 	TypeString
 	TypeBuiltinFunction
 	TypeError
+	TypeArray
+	TypeHash
+	TypeBreak
+	TypeContinue
+	TypeHostObject
+	TypeTailCall
+	TypeFloat
+	TypeBigInt
 )
 
 func (t Type) String() string {
@@ -49,6 +57,22 @@ func (t Type) String() string {
 		return "String"
 	case TypeBuiltinFunction:
 		return "Builtin Function"
+	case TypeArray:
+		return "Array"
+	case TypeHash:
+		return "Hash"
+	case TypeBreak:
+		return "Break"
+	case TypeContinue:
+		return "Continue"
+	case TypeHostObject:
+		return "Host Object"
+	case TypeTailCall:
+		return "Tail Call"
+	case TypeFloat:
+		return "Float"
+	case TypeBigInt:
+		return "BigInt"
 	default:
 		return "Null"
 	}
@@ -58,3 +82,11 @@ type Object interface {
 	Type() Type
 	Inspect() string
 }
+
+// PropertyAccessor is implemented by objects whose properties are accessible
+// by `object.name`. Property reports false if the object has no property
+// name, a property which can't be read is returned as an Error.
+type PropertyAccessor interface {
+	Object
+	Property(name string) (Object, bool)
+}
@@ -29,3 +29,7 @@ func (s *String) Type() Type {
 func (s *String) Inspect() string {
 	return s.Value
 }
+
+func (s *String) HashKey() HashKey {
+	return HashKey{Type: s.Type(), Text: s.Value}
+}
@@ -0,0 +1,50 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package parser
+
+import (
+	"github.com/suenchunyu/snow-lang/internal/ast"
+	"github.com/suenchunyu/snow-lang/internal/diag"
+)
+
+func (p *Parser) parseAssignExpression(target ast.Expression) ast.Expression {
+	exp := &ast.AssignExpression{
+		Token:    p.cur,
+		Target:   target,
+		Operator: p.cur.Literal,
+	}
+
+	switch target.(type) {
+	case *ast.Identifier, *ast.IndexExpression:
+	default:
+		// after a syntax error the target may be half built, it can't be
+		// printed and the error is already reported.
+		if target != nil && !p.panicking {
+			p.errorf(diag.CodeInvalidAssignment, p.cur.Span, "invalid assignment target: %s", target.String())
+		}
+		return nil
+	}
+
+	p.nextToken()
+
+	// assignments are right associative, `a = b = 1` assigns 1 to both.
+	exp.Value = p.parseExpression(Lowest)
+
+	return exp
+}
@@ -0,0 +1,100 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/suenchunyu/snow-lang/internal/ast"
+	"github.com/suenchunyu/snow-lang/internal/diag"
+	"github.com/suenchunyu/snow-lang/internal/lexer"
+	"github.com/suenchunyu/snow-lang/internal/parser"
+)
+
+func TestAssignExpressionParsing(t *testing.T) {
+	tests := []struct {
+		input    string
+		operator string
+		target   string
+		expected string
+	}{
+		{"a = 2;", "=", "a", "a = 2"},
+		{"a += 1 + 2", "+=", "a", "a += (1 + 2)"},
+		{"a -= b * c", "-=", "a", "a -= (b * c)"},
+		{"a *= 3", "*=", "a", "a *= 3"},
+		{"a /= 4", "/=", "a", "a /= 4"},
+		{"arr[3] = 6;", "=", "(arr[3])", "(arr[3]) = 6"},
+		{`map["age"] = 23;`, "=", "(map[age])", "(map[age]) = 23"},
+		{"a = b = 1", "=", "a", "a = b = 1"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := parser.New(l)
+		program := p.Parse()
+		checkParserErrors(t, p)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("program.Statements does not contain 1 statements. got = %d", len(program.Statements))
+		}
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		exp, ok := stmt.Expression.(*ast.AssignExpression)
+		if !ok {
+			t.Fatalf("stmt.Expression is not *ast.AssignExpression. got = %T", stmt.Expression)
+		}
+
+		if exp.Operator != tt.operator {
+			t.Errorf("exp.Operator is not %q. got = %q", tt.operator, exp.Operator)
+		}
+
+		if exp.Target.String() != tt.target {
+			t.Errorf("exp.Target is not %q. got = %q", tt.target, exp.Target.String())
+		}
+
+		if program.String() != tt.expected {
+			t.Errorf("expected = %q, got = %q", tt.expected, program.String())
+		}
+	}
+}
+
+func TestInvalidAssignmentTarget(t *testing.T) {
+	inputs := []string{
+		"1 = 2",
+		"a + b = 2",
+		"f() = 1",
+		`"a" += 1`,
+	}
+
+	for _, input := range inputs {
+		l := lexer.New(input)
+		p := parser.New(l)
+		p.Parse()
+
+		errors := p.Errors()
+		if len(errors) != 1 {
+			t.Errorf("wrong number of errors for %q, expected = 1, got = %d (%v)", input, len(errors), errors)
+			continue
+		}
+
+		if errors[0].Code != diag.CodeInvalidAssignment {
+			t.Errorf("wrong code for %q, expected = %q, got = %q", input, diag.CodeInvalidAssignment, errors[0].Code)
+		}
+	}
+}
@@ -19,9 +19,8 @@
 package parser
 
 import (
-	"fmt"
-
 	"github.com/suenchunyu/snow-lang/internal/ast"
+	"github.com/suenchunyu/snow-lang/internal/diag"
 	"github.com/suenchunyu/snow-lang/internal/token"
 )
 
@@ -78,17 +77,11 @@ func (p *Parser) parseGroupedExpression() ast.Expression {
 func (p *Parser) parseIfExpression() ast.Expression {
 	expression := &ast.IfExpression{Token: p.cur}
 
-	if !p.expectedPeek(token.FlagLParen) {
-		return nil
-	}
-
+	// the parentheses around the condition are optional, they are parsed
+	// as a grouped expression if present.
 	p.nextToken()
 	expression.Condition = p.parseExpression(Lowest)
 
-	if !p.expectedPeek(token.FlagRParen) {
-		return nil
-	}
-
 	if !p.expectedPeek(token.FlagLBrace) {
 		return nil
 	}
@@ -110,8 +103,12 @@ func (p *Parser) parseIfExpression() ast.Expression {
 func (p *Parser) parseExpression(precedence uint8) ast.Expression {
 	prefix := p.prefix[p.cur.Flag]
 	if prefix == nil {
-		msg := fmt.Sprintf("no prefix parse function for %s found", p.cur.Flag.String())
-		p.errors = append(p.errors, msg)
+		if p.curTokenIs(token.FlagIllegal) {
+			// already reported by the lexer.
+			p.panicking = true
+			return nil
+		}
+		p.errorf(diag.CodeExpectedExpression, p.cur.Span, "no prefix parse function for %s found", p.cur.Flag.String())
 		return nil
 	}
 
@@ -20,56 +20,128 @@ package parser
 
 import (
 	"github.com/suenchunyu/snow-lang/internal/ast"
+	"github.com/suenchunyu/snow-lang/internal/diag"
 	"github.com/suenchunyu/snow-lang/internal/token"
 )
 
 func (p *Parser) parseFunctionLiteral() ast.Expression {
 	lit := &ast.FunctionLiteral{Token: p.cur}
 
-	if !p.expectedPeek(token.FlagLParen) {
+	if !p.parseFunction(lit) {
 		return nil
 	}
+	return lit
+}
 
-	lit.Parameters = p.parseFunctionParameters()
+// parseFunctionStatement parses the declaration `fn name(params) { body }`.
+func (p *Parser) parseFunctionStatement() ast.Statement {
+	stmt := &ast.FunctionStatement{Token: p.cur}
 
-	if !p.expectedPeek(token.FlagLBrace) {
+	p.nextToken()
+	stmt.Name = &ast.Identifier{Token: p.cur, Value: p.cur.Literal}
+	stmt.Function = &ast.FunctionLiteral{Token: stmt.Token, Name: stmt.Name.Value}
+
+	if !p.parseFunction(stmt.Function) {
 		return nil
 	}
 
+	if p.peekTokenIs(token.FlagSemicolon) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+// parseFunction parses the parameters and the body of a function, starting
+// with the token before the opening parenthesis.
+func (p *Parser) parseFunction(lit *ast.FunctionLiteral) bool {
+	if !p.expectedPeek(token.FlagLParen) {
+		return false
+	}
+
+	if !p.parseFunctionParameters(lit) {
+		return false
+	}
+
+	if !p.expectedPeek(token.FlagLBrace) {
+		return false
+	}
+
 	lit.Body = p.parseBlockStatement()
+	markTailCalls(lit.Body, true)
 
-	return lit
+	return true
 }
 
-func (p *Parser) parseFunctionParameters() []*ast.Identifier {
-	identifiers := make([]*ast.Identifier, 0)
+// parseFunctionParameters parses the parameters of lit up to the closing
+// parenthesis, parameters may have a default value `x = 1` and the last one
+// may collect the remaining arguments `...rest`.
+func (p *Parser) parseFunctionParameters(lit *ast.FunctionLiteral) bool {
+	lit.Parameters = make([]*ast.Identifier, 0)
 
 	if p.peekTokenIs(token.FlagRParen) {
 		p.nextToken()
-		return identifiers
+		return true
 	}
 
-	p.nextToken()
+	for {
+		p.nextToken()
 
-	ident := &ast.Identifier{
-		Token: p.cur,
-		Value: p.cur.Literal,
-	}
-	identifiers = append(identifiers, ident)
+		if p.curTokenIs(token.FlagEllipsis) {
+			if !p.expectedPeek(token.FlagIdent) {
+				return false
+			}
+			lit.Rest = &ast.Identifier{Token: p.cur, Value: p.cur.Literal}
 
-	for p.peekTokenIs(token.FlagComma) {
-		p.nextToken()
+			if p.peekTokenIs(token.FlagComma) {
+				p.errorf(diag.CodeInvalidParameter, lit.Rest.Token.Span, "rest parameter %s must be the last parameter", lit.Rest.Value)
+				return false
+			}
+			break
+		}
+
+		if !p.curTokenIs(token.FlagIdent) {
+			p.errorf(diag.CodeInvalidParameter, p.cur.Span, "expected parameter name, got %s instead", p.cur.Flag)
+			return false
+		}
+
+		ident := &ast.Identifier{Token: p.cur, Value: p.cur.Literal}
+		if !p.parseParameterDefault(lit, ident) {
+			return false
+		}
+		lit.Parameters = append(lit.Parameters, ident)
+
+		if !p.peekTokenIs(token.FlagComma) {
+			break
+		}
 		p.nextToken()
-		ident := &ast.Identifier{
-			Token: p.cur,
-			Value: p.cur.Literal,
+	}
+
+	return p.expectedPeek(token.FlagRParen)
+}
+
+// parseParameterDefault parses the optional default value of param.
+func (p *Parser) parseParameterDefault(lit *ast.FunctionLiteral, param *ast.Identifier) bool {
+	if !p.peekTokenIs(token.FlagAssign) {
+		if lit.Defaults != nil {
+			p.errorf(diag.CodeInvalidParameter, param.Token.Span, "parameter %s without default value follows a parameter with one", param.Value)
+			return false
 		}
-		identifiers = append(identifiers, ident)
+		return true
 	}
 
-	if !p.expectedPeek(token.FlagRParen) {
-		return nil
+	p.nextToken()
+	p.nextToken()
+
+	value := p.parseExpression(Assign)
+	if value == nil {
+		return false
+	}
+
+	if lit.Defaults == nil {
+		lit.Defaults = make([]ast.Expression, len(lit.Parameters), len(lit.Parameters)+1)
 	}
+	lit.Defaults = append(lit.Defaults, value)
 
-	return identifiers
+	return true
 }
@@ -19,6 +19,7 @@
 package parser_test
 
 import (
+	"io/ioutil"
 	"testing"
 
 	"github.com/suenchunyu/snow-lang/internal/ast"
@@ -96,3 +97,154 @@ func TestFunctionParameterParsing(t *testing.T) {
 		}
 	}
 }
+
+func TestFunctionStatementParsing(t *testing.T) {
+	tests := []struct {
+		input          string
+		name           string
+		expectedParams []string
+		expected       string
+	}{
+		{"fn add(x, y) { x + y }", "add", []string{"x", "y"}, "fn add(x,y) (x + y)"},
+		{"fn noop() {};", "noop", []string{}, "fn noop() "},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := parser.New(l)
+		program := p.Parse()
+		checkParserErrors(t, p)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("program.Statements does not contain 1 statements. got = %d", len(program.Statements))
+		}
+
+		stmt, ok := program.Statements[0].(*ast.FunctionStatement)
+		if !ok {
+			t.Fatalf("program.Statements[0] is not *ast.FunctionStatement. got = %T", program.Statements[0])
+		}
+
+		if stmt.Name.Value != tt.name || stmt.Function.Name != tt.name {
+			t.Errorf("function name is not %q. got = %q, %q", tt.name, stmt.Name.Value, stmt.Function.Name)
+		}
+
+		if len(stmt.Function.Parameters) != len(tt.expectedParams) {
+			t.Errorf("length parameters wrong, want %d, got = %d\n", len(tt.expectedParams), len(stmt.Function.Parameters))
+		}
+
+		for i, ident := range tt.expectedParams {
+			testLiteralExpression(t, stmt.Function.Parameters[i], ident)
+		}
+
+		if program.String() != tt.expected {
+			t.Errorf("expected = %q, got = %q", tt.expected, program.String())
+		}
+	}
+}
+
+func TestParsingFibonacciSample(t *testing.T) {
+	source, err := ioutil.ReadFile("../../samples/fib.snow")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l := lexer.NewFile("fib.snow", string(source))
+	p := parser.New(l)
+	program := p.Parse()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 2 {
+		t.Fatalf("program.Statements does not contain 2 statements. got = %d", len(program.Statements))
+	}
+
+	if _, ok := program.Statements[0].(*ast.FunctionStatement); !ok {
+		t.Fatalf("program.Statements[0] is not *ast.FunctionStatement. got = %T", program.Statements[0])
+	}
+}
+
+func TestFunctionParameterDefaultsAndRest(t *testing.T) {
+	tests := []struct {
+		input    string
+		params   []string
+		defaults []string
+		rest     string
+		expected string
+	}{
+		{"fn(x, y = 1) {}", []string{"x", "y"}, []string{"", "1"}, "", "fn(x,y = 1) "},
+		{"fn(x = 1 + 2, y = x) {}", []string{"x", "y"}, []string{"(1 + 2)", "x"}, "", "fn(x = (1 + 2),y = x) "},
+		{"fn(...rest) {}", []string{}, nil, "rest", "fn(...rest) "},
+		{"fn(x, y = 2, ...rest) {}", []string{"x", "y"}, []string{"", "2"}, "rest", "fn(x,y = 2,...rest) "},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := parser.New(l)
+		program := p.Parse()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		function := stmt.Expression.(*ast.FunctionLiteral)
+
+		if len(function.Parameters) != len(tt.params) {
+			t.Fatalf("length parameters wrong, want %d, got = %d\n", len(tt.params), len(function.Parameters))
+		}
+
+		for i, ident := range tt.params {
+			testLiteralExpression(t, function.Parameters[i], ident)
+		}
+
+		if tt.defaults == nil && function.Defaults != nil {
+			t.Errorf("function.Defaults is not nil. got = %v", function.Defaults)
+		}
+
+		for i, def := range tt.defaults {
+			got := ""
+			if function.Defaults[i] != nil {
+				got = function.Defaults[i].String()
+			}
+			if got != def {
+				t.Errorf("default of parameter %d is not %q. got = %q", i, def, got)
+			}
+		}
+
+		if tt.rest == "" && function.Rest != nil {
+			t.Errorf("function.Rest is not nil. got = %s", function.Rest)
+		}
+
+		if tt.rest != "" && (function.Rest == nil || function.Rest.Value != tt.rest) {
+			t.Errorf("function.Rest is not %q. got = %v", tt.rest, function.Rest)
+		}
+
+		if program.String() != tt.expected {
+			t.Errorf("expected = %q, got = %q", tt.expected, program.String())
+		}
+	}
+}
+
+func TestInvalidFunctionParameters(t *testing.T) {
+	tests := []struct {
+		input   string
+		message string
+	}{
+		{"fn(x = 1, y) {}", "parameter y without default value follows a parameter with one"},
+		{"fn(...rest, x) {}", "rest parameter rest must be the last parameter"},
+		{"fn(1) {}", "expected parameter name, got INT instead"},
+		{"fn(...) {}", "expected next token to be IDENT, got ) instead"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := parser.New(l)
+		p.Parse()
+
+		errors := p.Errors()
+		if len(errors) == 0 {
+			t.Errorf("expected a syntax error for %q", tt.input)
+			continue
+		}
+
+		if errors[0].Message != tt.message {
+			t.Errorf("wrong error message for %q, expected = %q, got = %q", tt.input, tt.message, errors[0].Message)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/suenchunyu/snow-lang/internal/ast"
+	"github.com/suenchunyu/snow-lang/internal/lexer"
+	"github.com/suenchunyu/snow-lang/internal/parser"
+)
+
+func TestParsingHashLiteralsStringKeys(t *testing.T) {
+	input := `{"one": 1, "two": 2, "three": 3}`
+
+	l := lexer.New(input)
+	p := parser.New(l)
+	program := p.Parse()
+	checkParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	hash, ok := stmt.Expression.(*ast.HashLiteral)
+	if !ok {
+		t.Fatalf("exp is not *ast.HashLiteral. got = %T", stmt.Expression)
+	}
+
+	expected := []struct {
+		key   string
+		value int64
+	}{
+		{"one", 1},
+		{"two", 2},
+		{"three", 3},
+	}
+
+	if len(hash.Pairs) != len(expected) {
+		t.Fatalf("hash.Pairs has wrong length. got = %d", len(hash.Pairs))
+	}
+
+	for idx, pair := range hash.Pairs {
+		literal, ok := pair.Key.(*ast.StringLiteral)
+		if !ok {
+			t.Errorf("key is not *ast.StringLiteral. got = %T", pair.Key)
+			continue
+		}
+
+		if literal.Value != expected[idx].key {
+			t.Errorf("pairs[%d] - wrong key, expected = %q, got = %q", idx, expected[idx].key, literal.Value)
+		}
+
+		testIntegerLiteral(t, pair.Value, expected[idx].value)
+	}
+}
+
+func TestParsingHashLiteralsMixedKeys(t *testing.T) {
+	input := `{1: true, true: "x", "a" + "b": 2 * 3}`
+
+	l := lexer.New(input)
+	p := parser.New(l)
+	program := p.Parse()
+	checkParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	hash, ok := stmt.Expression.(*ast.HashLiteral)
+	if !ok {
+		t.Fatalf("exp is not *ast.HashLiteral. got = %T", stmt.Expression)
+	}
+
+	if len(hash.Pairs) != 3 {
+		t.Fatalf("hash.Pairs has wrong length. got = %d", len(hash.Pairs))
+	}
+
+	testIntegerLiteral(t, hash.Pairs[0].Key, 1)
+	testBooleanLiteral(t, hash.Pairs[0].Value, true)
+	testBooleanLiteral(t, hash.Pairs[1].Key, true)
+	testInfixExpression(t, hash.Pairs[2].Value, 2, "*", 3)
+}
+
+func TestParsingEmptyHashLiteral(t *testing.T) {
+	input := "{}"
+
+	l := lexer.New(input)
+	p := parser.New(l)
+	program := p.Parse()
+	checkParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	hash, ok := stmt.Expression.(*ast.HashLiteral)
+	if !ok {
+		t.Fatalf("exp is not *ast.HashLiteral. got = %T", stmt.Expression)
+	}
+
+	if len(hash.Pairs) != 0 {
+		t.Errorf("hash.Pairs has wrong length. got = %d", len(hash.Pairs))
+	}
+}
+
+func TestParsingMalformedHashLiteral(t *testing.T) {
+	inputs := []string{
+		`{"a" 1}`,
+		`{"a": 1 "b": 2}`,
+		`{"a": 1,`,
+	}
+
+	for _, input := range inputs {
+		l := lexer.New(input)
+		p := parser.New(l)
+		p.Parse()
+
+		if len(p.Errors()) != 1 {
+			t.Errorf("wrong number of errors for %q, expected = 1, got = %d (%v)", input, len(p.Errors()), p.Errors())
+		}
+	}
+}
@@ -34,3 +34,14 @@ func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
 	}
 	return exp
 }
+
+func (p *Parser) parsePropertyExpression(left ast.Expression) ast.Expression {
+	exp := &ast.PropertyExpression{Token: p.cur, Object: left}
+
+	if !p.expectedPeek(token.FlagIdent) {
+		return nil
+	}
+
+	exp.Property = &ast.Identifier{Token: p.cur, Value: p.cur.Literal}
+	return exp
+}
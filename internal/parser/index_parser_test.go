@@ -48,3 +48,42 @@ func TestParsingIndexExpression(t *testing.T) {
 		return
 	}
 }
+
+func TestParsingPropertyExpressions(t *testing.T) {
+	l := lexer.New("request.path")
+	p := parser.New(l)
+	program := p.Parse()
+	checkParserErrors(t, p)
+
+	stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not *ast.ExpressionStatement. got = %T", program.Statements[0])
+	}
+
+	exp, ok := stmt.Expression.(*ast.PropertyExpression)
+	if !ok {
+		t.Fatalf("exp is not *ast.PropertyExpression. got = %T", stmt.Expression)
+	}
+
+	if !testIdentifier(t, exp.Object, "request") {
+		return
+	}
+
+	if exp.Property.Value != "path" {
+		t.Errorf("exp.Property is not %q. got = %q", "path", exp.Property.Value)
+	}
+}
+
+func TestInvalidPropertyExpression(t *testing.T) {
+	inputs := []string{"a.", "a.1", "a.(b)"}
+
+	for _, input := range inputs {
+		l := lexer.New(input)
+		p := parser.New(l)
+		p.Parse()
+
+		if len(p.Errors()) == 0 {
+			t.Errorf("expected a syntax error for %q", input)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package parser
+
+import (
+	"github.com/suenchunyu/snow-lang/internal/ast"
+	"github.com/suenchunyu/snow-lang/internal/diag"
+	"github.com/suenchunyu/snow-lang/internal/token"
+)
+
+// parseForStatement parses both `for (init; condition; post) { ... }` and
+// `for x in iterable { ... }`, the parentheses are optional for the latter.
+func (p *Parser) parseForStatement() ast.Statement {
+	tok := p.cur
+
+	parenthesized := p.peekTokenIs(token.FlagLParen)
+	if parenthesized {
+		p.nextToken()
+	}
+	p.nextToken()
+
+	if p.curTokenIs(token.FlagIdent) && p.peekTokenIs(token.FlagIn) {
+		return p.parseForInStatement(tok, parenthesized)
+	}
+
+	if !parenthesized {
+		p.errorf(diag.CodeUnexpectedToken, p.cur.Span, "expected ( or a loop variable after for, got %s instead", p.cur.Flag)
+		return nil
+	}
+
+	return p.parseCStyleForStatement(tok)
+}
+
+func (p *Parser) parseCStyleForStatement(tok *token.Token) ast.Statement {
+	stmt := &ast.ForStatement{Token: tok}
+
+	if !p.curTokenIs(token.FlagSemicolon) {
+		switch p.cur.Flag {
+		case token.FlagLet:
+			stmt.Init = p.parseLetStatement()
+		default:
+			stmt.Init = p.parseExpressionStatement()
+		}
+
+		if p.panicking {
+			return nil
+		}
+
+		if !p.curTokenIs(token.FlagSemicolon) && !p.expectedPeek(token.FlagSemicolon) {
+			return nil
+		}
+	}
+
+	if p.peekTokenIs(token.FlagSemicolon) {
+		p.nextToken()
+	} else {
+		p.nextToken()
+		stmt.Condition = p.parseExpression(Lowest)
+		if !p.expectedPeek(token.FlagSemicolon) {
+			return nil
+		}
+	}
+
+	if p.peekTokenIs(token.FlagRParen) {
+		p.nextToken()
+	} else {
+		p.nextToken()
+		stmt.Post = p.parseExpression(Lowest)
+		if !p.expectedPeek(token.FlagRParen) {
+			return nil
+		}
+	}
+
+	if !p.expectedPeek(token.FlagLBrace) {
+		return nil
+	}
+
+	stmt.Body = p.parseLoopBody()
+
+	return stmt
+}
+
+func (p *Parser) parseForInStatement(tok *token.Token, parenthesized bool) ast.Statement {
+	stmt := &ast.ForInStatement{
+		Token: tok,
+		Variable: &ast.Identifier{
+			Token: p.cur,
+			Value: p.cur.Literal,
+		},
+	}
+
+	p.nextToken()
+	p.nextToken()
+
+	stmt.Iterable = p.parseExpression(Lowest)
+
+	if parenthesized && !p.expectedPeek(token.FlagRParen) {
+		return nil
+	}
+
+	if !p.expectedPeek(token.FlagLBrace) {
+		return nil
+	}
+
+	stmt.Body = p.parseLoopBody()
+
+	return stmt
+}
+
+func (p *Parser) parseWhileStatement() ast.Statement {
+	stmt := &ast.WhileStatement{Token: p.cur}
+
+	p.nextToken()
+	stmt.Condition = p.parseExpression(Lowest)
+
+	if !p.expectedPeek(token.FlagLBrace) {
+		return nil
+	}
+
+	stmt.Body = p.parseLoopBody()
+
+	return stmt
+}
+
+// parseLoopBody parses the block of a loop, a semicolon following the
+// closing brace is allowed.
+func (p *Parser) parseLoopBody() *ast.BlockStatement {
+	body := p.parseBlockStatement()
+
+	if p.peekTokenIs(token.FlagSemicolon) {
+		p.nextToken()
+	}
+
+	return body
+}
+
+func (p *Parser) parseBreakStatement() ast.Statement {
+	stmt := &ast.BreakStatement{Token: p.cur}
+
+	if p.peekTokenIs(token.FlagSemicolon) {
+		p.nextToken()
+	}
+	return stmt
+}
+
+func (p *Parser) parseContinueStatement() ast.Statement {
+	stmt := &ast.ContinueStatement{Token: p.cur}
+
+	if p.peekTokenIs(token.FlagSemicolon) {
+		p.nextToken()
+	}
+	return stmt
+}
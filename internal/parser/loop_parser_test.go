@@ -0,0 +1,137 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/suenchunyu/snow-lang/internal/ast"
+	"github.com/suenchunyu/snow-lang/internal/lexer"
+	"github.com/suenchunyu/snow-lang/internal/parser"
+)
+
+func TestForStatement(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"for (let i = 0; i < 10; i += 1) { x }", "for (let i = 0; (i < 10); i += 1) x"},
+		{"for (i = 0; i < 10; i = i + 1) { x; };", "for (i = 0; (i < 10); i = (i + 1)) x"},
+		{"for (;;) { break; }", "for (; ; ) break;"},
+		{"for (; i < 10;) { continue }", "for (; (i < 10); ) continue;"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := parser.New(l)
+		program := p.Parse()
+		checkParserErrors(t, p)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("program.Statements does not contain 1 statements. got = %d", len(program.Statements))
+		}
+
+		if _, ok := program.Statements[0].(*ast.ForStatement); !ok {
+			t.Fatalf("program.Statements[0] is not *ast.ForStatement. got = %T", program.Statements[0])
+		}
+
+		if program.String() != tt.expected {
+			t.Errorf("expected = %q, got = %q", tt.expected, program.String())
+		}
+	}
+}
+
+func TestForInStatement(t *testing.T) {
+	tests := []struct {
+		input    string
+		variable string
+		iterable string
+	}{
+		{"for x in [1, 2] { x }", "x", "[1, 2]"},
+		{"for (key in map) { key }", "key", "map"},
+		{"for c in f(s) { c };", "c", "f(s)"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := parser.New(l)
+		program := p.Parse()
+		checkParserErrors(t, p)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("program.Statements does not contain 1 statements. got = %d", len(program.Statements))
+		}
+
+		stmt, ok := program.Statements[0].(*ast.ForInStatement)
+		if !ok {
+			t.Fatalf("program.Statements[0] is not *ast.ForInStatement. got = %T", program.Statements[0])
+		}
+
+		if stmt.Variable.Value != tt.variable {
+			t.Errorf("stmt.Variable is not %q. got = %q", tt.variable, stmt.Variable.Value)
+		}
+
+		if stmt.Iterable.String() != tt.iterable {
+			t.Errorf("stmt.Iterable is not %q. got = %q", tt.iterable, stmt.Iterable.String())
+		}
+	}
+}
+
+func TestWhileStatement(t *testing.T) {
+	l := lexer.New("while (x < 10) { x += 1; if (x == 5) { break; } }")
+	p := parser.New(l)
+	program := p.Parse()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program.Statements does not contain 1 statements. got = %d", len(program.Statements))
+	}
+
+	stmt, ok := program.Statements[0].(*ast.WhileStatement)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not *ast.WhileStatement. got = %T", program.Statements[0])
+	}
+
+	if stmt.Condition.String() != "(x < 10)" {
+		t.Errorf("stmt.Condition is not %q. got = %q", "(x < 10)", stmt.Condition.String())
+	}
+
+	if len(stmt.Body.Statements) != 2 {
+		t.Fatalf("stmt.Body.Statements does not contain 2 statements. got = %d", len(stmt.Body.Statements))
+	}
+}
+
+func TestInvalidForStatement(t *testing.T) {
+	inputs := []string{
+		"for x { }",
+		"for (let i = 0; i < 10 { }",
+		"for (let i = 0; i < 10; i += 1 { }",
+		"for (x in xs { }",
+	}
+
+	for _, input := range inputs {
+		l := lexer.New(input)
+		p := parser.New(l)
+		p.Parse()
+
+		if len(p.Errors()) == 0 {
+			t.Errorf("expected a syntax error for %q", input)
+		}
+	}
+}
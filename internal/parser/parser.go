@@ -22,6 +22,7 @@ import (
 	"fmt"
 
 	"github.com/suenchunyu/snow-lang/internal/ast"
+	"github.com/suenchunyu/snow-lang/internal/diag"
 	"github.com/suenchunyu/snow-lang/internal/lexer"
 	"github.com/suenchunyu/snow-lang/internal/token"
 )
@@ -29,13 +30,16 @@ import (
 const (
 	_ uint8 = iota
 	Lowest
+	Assign      // = or +=
+	LogicalOr   // ||
+	LogicalAnd  // &&
 	Equals      // ==
-	LessGreater // > or <
-	Sum         // +
-	Product     // *
-	Prefix      // -x or !x
+	LessGreater // > or <=
+	Sum         // +, | or ^
+	Product     // *, %, & or <<
+	Prefix      // -x, !x or ~x
 	Call        // customFun(x)
-	Index       // array[index]
+	Index       // array[index] or object.property
 )
 
 type (
@@ -45,7 +49,18 @@ type (
 
 type Parser struct {
 	l      *lexer.Lexer
-	errors []string
+	errors []*diag.Diagnostic
+	// lexed counts the lexer diagnostics already merged into errors.
+	lexed int
+
+	// panicking is set once a syntax error is reported and cleared when
+	// the parser synchronizes at the next statement boundary, errors
+	// reported in between are most likely follow-ups and dropped.
+	panicking bool
+	// aborted is set once too many errors were reported.
+	aborted bool
+	// depth is the number of enclosing block statements.
+	depth int
 
 	cur  *token.Token
 	peek *token.Token
@@ -57,21 +72,25 @@ type Parser struct {
 func New(l *lexer.Lexer) *Parser {
 	p := &Parser{
 		l:      l,
-		errors: make([]string, 0),
+		errors: make([]*diag.Diagnostic, 0),
 	}
 
 	p.prefix = make(map[token.Flag]prefixParseFunc)
 	p.registerPrefix(token.FlagIdent, p.parseIdentifier)
 	p.registerPrefix(token.FlagInt, p.parseIntegerLiteral)
+	p.registerPrefix(token.FlagFloat, p.parseFloatLiteral)
 	p.registerPrefix(token.FlagEM, p.parsePrefixExpression)
 	p.registerPrefix(token.FlagMinus, p.parsePrefixExpression)
+	p.registerPrefix(token.FlagTilde, p.parsePrefixExpression)
 	p.registerPrefix(token.FlagTrue, p.parseBoolean)
 	p.registerPrefix(token.FlagFalse, p.parseBoolean)
 	p.registerPrefix(token.FlagLParen, p.parseGroupedExpression)
 	p.registerPrefix(token.FlagIf, p.parseIfExpression)
 	p.registerPrefix(token.FlagFunction, p.parseFunctionLiteral)
 	p.registerPrefix(token.FlagString, p.parseStringLiteral)
+	p.registerPrefix(token.FlagTemplateStart, p.parseTemplateLiteral)
 	p.registerPrefix(token.FlagLBracket, p.parseArrayLiteral)
+	p.registerPrefix(token.FlagLBrace, p.parseHashLiteral)
 
 	p.infix = make(map[token.Flag]infixParserFunc)
 	p.registerInfix(token.FlagPlus, p.parseInfixExpression)
@@ -82,8 +101,25 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerInfix(token.FlagNotEqual, p.parseInfixExpression)
 	p.registerInfix(token.FlagLessThan, p.parseInfixExpression)
 	p.registerInfix(token.FlagGreaterThan, p.parseInfixExpression)
+	p.registerInfix(token.FlagLessEqual, p.parseInfixExpression)
+	p.registerInfix(token.FlagGreaterEqual, p.parseInfixExpression)
+	p.registerInfix(token.FlagPercent, p.parseInfixExpression)
+	p.registerInfix(token.FlagAnd, p.parseInfixExpression)
+	p.registerInfix(token.FlagOr, p.parseInfixExpression)
+	p.registerInfix(token.FlagAmpersand, p.parseInfixExpression)
+	p.registerInfix(token.FlagPipe, p.parseInfixExpression)
+	p.registerInfix(token.FlagCaret, p.parseInfixExpression)
+	p.registerInfix(token.FlagShiftLeft, p.parseInfixExpression)
+	p.registerInfix(token.FlagShiftRight, p.parseInfixExpression)
 	p.registerInfix(token.FlagLParen, p.parseCallExpression)
 	p.registerInfix(token.FlagLBracket, p.parseIndexExpression)
+	p.registerInfix(token.FlagDot, p.parsePropertyExpression)
+	p.registerInfix(token.FlagAssign, p.parseAssignExpression)
+	p.registerInfix(token.FlagPlusAssign, p.parseAssignExpression)
+	p.registerInfix(token.FlagMinusAssign, p.parseAssignExpression)
+	p.registerInfix(token.FlagAsteriskAssign, p.parseAssignExpression)
+	p.registerInfix(token.FlagSlashAssign, p.parseAssignExpression)
+	p.registerInfix(token.FlagPercentAssign, p.parseAssignExpression)
 
 	p.nextToken()
 	p.nextToken()
@@ -100,16 +136,33 @@ func (p *Parser) registerInfix(flag token.Flag, fn infixParserFunc) {
 }
 
 var precedences = map[token.Flag]uint8{
-	token.FlagEqual:       Equals,
-	token.FlagNotEqual:    Equals,
-	token.FlagLessThan:    LessGreater,
-	token.FlagGreaterThan: LessGreater,
-	token.FlagPlus:        Sum,
-	token.FlagMinus:       Sum,
-	token.FlagSlash:       Product,
-	token.FlagAsterisk:    Product,
-	token.FlagLParen:      Call,
-	token.FlagLBracket:    Index,
+	token.FlagAssign:         Assign,
+	token.FlagPlusAssign:     Assign,
+	token.FlagMinusAssign:    Assign,
+	token.FlagAsteriskAssign: Assign,
+	token.FlagSlashAssign:    Assign,
+	token.FlagPercentAssign:  Assign,
+	token.FlagOr:             LogicalOr,
+	token.FlagAnd:            LogicalAnd,
+	token.FlagEqual:          Equals,
+	token.FlagNotEqual:       Equals,
+	token.FlagLessThan:       LessGreater,
+	token.FlagGreaterThan:    LessGreater,
+	token.FlagLessEqual:      LessGreater,
+	token.FlagGreaterEqual:   LessGreater,
+	token.FlagPlus:           Sum,
+	token.FlagMinus:          Sum,
+	token.FlagSlash:          Product,
+	token.FlagAsterisk:       Product,
+	token.FlagPercent:        Product,
+	token.FlagPipe:           Sum,
+	token.FlagCaret:          Sum,
+	token.FlagAmpersand:      Product,
+	token.FlagShiftLeft:      Product,
+	token.FlagShiftRight:     Product,
+	token.FlagLParen:         Call,
+	token.FlagLBracket:       Index,
+	token.FlagDot:            Index,
 }
 
 func (p *Parser) peekPrecedence() uint8 {
@@ -127,8 +180,20 @@ func (p *Parser) curPrecedence() uint8 {
 }
 
 func (p *Parser) nextToken() {
+	if p.aborted {
+		// pretend the input ended so that every loop of the parser stops.
+		eof := &token.Token{Flag: token.FlagEOF, Span: p.peek.Span}
+		p.cur, p.peek = eof, eof
+		return
+	}
+
 	p.cur = p.peek
 	p.peek = p.l.NextToken()
+
+	lexed := p.l.Diagnostics()
+	for ; p.lexed < len(lexed); p.lexed++ {
+		p.report(lexed[p.lexed])
+	}
 }
 
 func (p *Parser) Parse() *ast.Program {
@@ -137,6 +202,11 @@ func (p *Parser) Parse() *ast.Program {
 
 	for p.cur.Flag != token.FlagEOF {
 		stmt := p.parseStatement()
+		if p.panicking {
+			p.synchronize()
+			continue
+		}
+
 		if stmt != nil {
 			program.Statements = append(program.Statements, stmt)
 		}
@@ -146,7 +216,8 @@ func (p *Parser) Parse() *ast.Program {
 	return program
 }
 
-func (p *Parser) Errors() []string {
+// Errors returns the diagnostics reported by both the lexer and the parser.
+func (p *Parser) Errors() []*diag.Diagnostic {
 	return p.errors
 }
 
@@ -169,6 +240,37 @@ func (p *Parser) expectedPeek(t token.Flag) bool {
 }
 
 func (p *Parser) peekError(t token.Flag) {
-	msg := fmt.Sprintf("expected next token to be %s, got %s instead", t.String(), p.peek.Flag)
-	p.errors = append(p.errors, msg)
+	// illegal characters have already been reported by the lexer.
+	if p.peekTokenIs(token.FlagIllegal) {
+		p.panicking = true
+		return
+	}
+
+	d := p.errorf(diag.CodeUnexpectedToken, p.peek.Span, "expected next token to be %s, got %s instead", t.String(), p.peek.Flag)
+	if d == nil {
+		return
+	}
+
+	switch t {
+	case token.FlagRParen, token.FlagRBracket, token.FlagRBrace:
+		end := token.Span{Start: p.cur.Span.End, End: p.cur.Span.End}
+		d.Fix = &diag.Fix{
+			Message:     fmt.Sprintf("insert `%s`", t),
+			Span:        end,
+			Replacement: t.String(),
+		}
+	}
+}
+
+// errorf reports a syntax error and puts the parser into panic mode. It
+// returns nil if the error was dropped as a follow-up of a previous one.
+func (p *Parser) errorf(code diag.Code, span token.Span, format string, args ...interface{}) *diag.Diagnostic {
+	if p.panicking || p.aborted {
+		return nil
+	}
+	p.panicking = true
+
+	d := diag.Errorf(code, span, format, args...)
+	p.report(d)
+	return d
 }
@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"github.com/suenchunyu/snow-lang/internal/ast"
+	"github.com/suenchunyu/snow-lang/internal/diag"
 	"github.com/suenchunyu/snow-lang/internal/lexer"
 	"github.com/suenchunyu/snow-lang/internal/parser"
 )
@@ -84,6 +85,84 @@ func TestIntegerLiteralExpression(t *testing.T) {
 	}
 }
 
+func TestIntegerLiteralForms(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"0xFF", 255},
+		{"0Xff", 255},
+		{"0o17", 15},
+		{"017", 15},
+		{"0b1010", 10},
+		{"1_000_000", 1000000},
+		{"0x_FF_FF", 65535},
+		{"0", 0},
+	}
+
+	for _, tt := range tests {
+		p := parser.New(lexer.New(tt.input))
+		program := p.Parse()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		literal, ok := stmt.Expression.(*ast.IntegerLiteral)
+		if !ok {
+			t.Fatalf("exp is not *ast.IntegerLiteral. got = %T", stmt.Expression)
+		}
+
+		if literal.Value != tt.expected {
+			t.Errorf("%s: literal.Value not %d. got = %d", tt.input, tt.expected, literal.Value)
+		}
+	}
+}
+
+func TestMalformedNumberIsReportedOnce(t *testing.T) {
+	p := parser.New(lexer.New("let a = 0x;"))
+	p.Parse()
+
+	if len(p.Errors()) != 1 || p.Errors()[0].Code != diag.CodeMalformedNumber {
+		t.Errorf("expected a single malformed-number error, got = %v", p.Errors())
+	}
+}
+
+func TestFloatLiteralExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+	}{
+		{"3.14;", 3.14},
+		{"1e3", 1000},
+		{"2.5e-1", 0.25},
+	}
+
+	for _, tt := range tests {
+		p := parser.New(lexer.New(tt.input))
+		program := p.Parse()
+		checkParserErrors(t, p)
+
+		stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+		if !ok {
+			t.Fatalf("program.Statements[0] is not *ast.ExpressionStatement. got = %T", program.Statements[0])
+		}
+
+		literal, ok := stmt.Expression.(*ast.FloatLiteral)
+		if !ok {
+			t.Fatalf("exp is not *ast.FloatLiteral. got = %T", stmt.Expression)
+		}
+
+		if literal.Value != tt.expected {
+			t.Errorf("literal.Value not %g. got = %g", tt.expected, literal.Value)
+		}
+	}
+
+	p := parser.New(lexer.New("1e400"))
+	p.Parse()
+	if len(p.Errors()) != 1 || p.Errors()[0].Code != diag.CodeInvalidFloat {
+		t.Errorf("expected an invalid-float error, got = %v", p.Errors())
+	}
+}
+
 func TestParsingPrefixExpression(t *testing.T) {
 	prefixTests := []struct {
 		input    string
@@ -179,6 +258,11 @@ func TestParsingInfixExpressions(t *testing.T) {
 		{"true == true", true, "==", true},
 		{"true != false", true, "!=", false},
 		{"false == false", false, "==", false},
+		{"5 <= 5", 5, "<=", 5},
+		{"5 >= 5", 5, ">=", 5},
+		{"5 % 5", 5, "%", 5},
+		{"true && false", true, "&&", false},
+		{"true || false", true, "||", false},
 	}
 
 	for _, tt := range infixTests {
@@ -314,6 +398,58 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 		{"add(a * b[2], b[1], 2 * [1, 2][1])",
 			"add((a * (b[2])), (b[1]), (2 * ([1, 2][1])))",
 		},
+		{
+			"a + b % c * d",
+			"(a + ((b % c) * d))",
+		},
+		{
+			"a <= b == c >= d",
+			"((a <= b) == (c >= d))",
+		},
+		{
+			"a == b && c != d",
+			"((a == b) && (c != d))",
+		},
+		{
+			"a || b && c || d",
+			"((a || (b && c)) || d)",
+		},
+		{
+			"a | b & c ^ d",
+			"((a | (b & c)) ^ d)",
+		},
+		{
+			"a + b << c",
+			"(a + (b << c))",
+		},
+		{
+			"a >> b * c & d",
+			"(((a >> b) * c) & d)",
+		},
+		{
+			"a & b == c | d",
+			"((a & b) == (c | d))",
+		},
+		{
+			"~a & -b",
+			"((~a) & (-b))",
+		},
+		{
+			"a < b << c",
+			"(a < (b << c))",
+		},
+		{
+			"x = a || b",
+			"x = (a || b)",
+		},
+		{
+			"a.b.c(1) + d.e[2]",
+			"(((a.b).c)(1) + ((d.e)[2]))",
+		},
+		{
+			"-req.size * 2",
+			"((-(req.size)) * 2)",
+		},
 	}
 
 	for _, tt := range tests {
@@ -413,6 +549,33 @@ func TestIfElseExpression(t *testing.T) {
 	}
 }
 
+func TestParserErrorPositions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let = 5;", "1:5: expected next token to be IDENT, got = instead"},
+		{"let x = 5;\nadd(1, 2", "2:9: expected next token to be ), got EOF instead"},
+		{"let x = 5;\n  let y = ;", "2:11: no prefix parse function for ; found"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := parser.New(l)
+		p.Parse()
+
+		errors := p.Errors()
+		if len(errors) == 0 {
+			t.Errorf("expected parser errors for %q", tt.input)
+			continue
+		}
+
+		if errors[0].Error() != tt.expected {
+			t.Errorf("wrong error, expected = %q, got = %q", tt.expected, errors[0])
+		}
+	}
+}
+
 func testInfixExpression(t *testing.T, exp ast.Expression, left interface{}, operator string, right interface{}) bool {
 	opExp, ok := exp.(*ast.InfixExpression)
 	if !ok {
@@ -517,3 +680,55 @@ func checkParserErrors(t *testing.T, p *parser.Parser) {
 	}
 	t.FailNow()
 }
+
+func TestParsingWithComments(t *testing.T) {
+	input := `
+// adds two numbers
+let add = fn(x, y) {
+  // return is optional
+  x /* plus */ + y
+};
+add(1, 2); // 3
+`
+
+	l := lexer.New(input)
+	p := parser.New(l)
+	program := p.Parse()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 2 {
+		t.Fatalf("program.Statements does not contain 2 statements. got = %d", len(program.Statements))
+	}
+
+	expected := "let add = fn(x,y) (x + y);add(1, 2)"
+	if program.String() != expected {
+		t.Errorf("expected = %q, got = %q", expected, program.String())
+	}
+
+	let := program.Statements[0].(*ast.LetStatement)
+	if len(let.Token.Leading) != 1 || let.Token.Leading[0].Text != "// adds two numbers" {
+		t.Errorf("comment is not attached to the let token, got = %+v", let.Token.Leading)
+	}
+}
+
+func TestIfExpressionWithoutParentheses(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"if x < y { x }", "if(x < y) x"},
+		{"if x { x } else { y }", "ifx xelse y"},
+		{"if (a) + b { c }", "if(a + b) c"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := parser.New(l)
+		program := p.Parse()
+		checkParserErrors(t, p)
+
+		if program.String() != tt.expected {
+			t.Errorf("expected = %q, got = %q", tt.expected, program.String())
+		}
+	}
+}
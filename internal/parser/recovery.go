@@ -0,0 +1,93 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package parser
+
+import (
+	"github.com/suenchunyu/snow-lang/internal/diag"
+	"github.com/suenchunyu/snow-lang/internal/token"
+)
+
+// MaxErrors is the number of diagnostics after which the parser gives up.
+const MaxErrors = 10
+
+// report records d, stopping the parser once MaxErrors is reached.
+func (p *Parser) report(d *diag.Diagnostic) {
+	if p.aborted {
+		return
+	}
+
+	if len(p.errors) == MaxErrors {
+		p.aborted = true
+		p.errors = append(p.errors, &diag.Diagnostic{
+			Severity: diag.SeverityNote,
+			Code:     diag.CodeTooManyErrors,
+			Message:  "too many errors, aborting",
+			Span:     d.Span,
+		})
+		return
+	}
+
+	p.errors = append(p.errors, d)
+}
+
+// synchronize leaves panic mode by skipping tokens until the start of the
+// next statement: past a ';', in front of a statement keyword, or at the
+// '}' closing the enclosing block. Braces skipped on the way are balanced so
+// that a broken statement containing a block is dropped as a whole.
+func (p *Parser) synchronize() {
+	p.panicking = false
+
+	nesting := 0
+	for {
+		switch p.cur.Flag {
+		case token.FlagEOF:
+			return
+		case token.FlagLBrace:
+			nesting += 1
+		case token.FlagRBrace:
+			if nesting == 0 && p.depth > 0 {
+				return
+			}
+			if nesting > 0 {
+				nesting -= 1
+			}
+		case token.FlagSemicolon:
+			if nesting == 0 {
+				p.nextToken()
+				return
+			}
+		}
+
+		p.nextToken()
+
+		if nesting == 0 && isStatementKeyword(p.cur.Flag) {
+			return
+		}
+	}
+}
+
+func isStatementKeyword(flag token.Flag) bool {
+	switch flag {
+	case token.FlagLet, token.FlagReturn, token.FlagFunction,
+		token.FlagFor, token.FlagWhile, token.FlagBreak, token.FlagContinue:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,166 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package parser_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/suenchunyu/snow-lang/internal/diag"
+	"github.com/suenchunyu/snow-lang/internal/lexer"
+	"github.com/suenchunyu/snow-lang/internal/parser"
+)
+
+func TestErrorRecovery(t *testing.T) {
+	tests := []struct {
+		input              string
+		expectedCodes      []diag.Code
+		expectedStatements int
+	}{
+		{
+			"let a = 1;\nlet b = (2 + ;\nlet c = 3;\nlet d = 4;",
+			[]diag.Code{diag.CodeExpectedExpression},
+			3,
+		},
+		{
+			"let a = 1\nlet b = add(1 2, 3)\nlet c = a",
+			[]diag.Code{diag.CodeUnexpectedToken},
+			2,
+		},
+		{
+			"let f = fn(x) { let y = ; x }; f(1);",
+			[]diag.Code{diag.CodeExpectedExpression},
+			2,
+		},
+		{
+			"let f = fn(x y) { x + y; }; let g = 1;",
+			[]diag.Code{diag.CodeUnexpectedToken},
+			1,
+		},
+		{
+			"} let a = 1;",
+			[]diag.Code{diag.CodeExpectedExpression},
+			1,
+		},
+		{
+			"let a = @; let b = 2;",
+			[]diag.Code{diag.CodeIllegalCharacter},
+			1,
+		},
+		{
+			"let a = \"abc;\nlet b = 2;",
+			[]diag.Code{diag.CodeUnterminatedString},
+			1,
+		},
+		{
+			"let a = 0x; let b = 2;",
+			[]diag.Code{diag.CodeMalformedNumber},
+			1,
+		},
+		{
+			"let a = 1; let = 2; let = 3;",
+			[]diag.Code{diag.CodeUnexpectedToken, diag.CodeUnexpectedToken},
+			1,
+		},
+		{
+			"-) = 1; let b = 2;",
+			[]diag.Code{diag.CodeExpectedExpression},
+			1,
+		},
+		{
+			"a[!] = 1; let b = 2;",
+			[]diag.Code{diag.CodeExpectedExpression},
+			1,
+		},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := parser.New(l)
+		program := p.Parse()
+
+		errors := p.Errors()
+		if len(errors) != len(tt.expectedCodes) {
+			t.Errorf("wrong number of errors for %q, expected = %d, got = %d (%v)", tt.input, len(tt.expectedCodes), len(errors), errors)
+			continue
+		}
+
+		for idx, code := range tt.expectedCodes {
+			if errors[idx].Code != code {
+				t.Errorf("errors[%d] - wrong code for %q, expected = %q, got = %q", idx, tt.input, code, errors[idx].Code)
+			}
+		}
+
+		if len(program.Statements) != tt.expectedStatements {
+			t.Errorf("wrong number of statements for %q, expected = %d, got = %d", tt.input, tt.expectedStatements, len(program.Statements))
+		}
+	}
+}
+
+func TestUnterminatedBlock(t *testing.T) {
+	inputs := []string{
+		"fn(x) { x + 1",
+		"if (x) { if (y) { 1 }",
+		"let f = fn() {",
+		"{",
+	}
+
+	for _, input := range inputs {
+		l := lexer.New(input)
+		p := parser.New(l)
+		p.Parse()
+
+		errors := p.Errors()
+		if len(errors) != 1 {
+			t.Errorf("wrong number of errors for %q, expected = 1, got = %d (%v)", input, len(errors), errors)
+			continue
+		}
+
+		if input == "{" {
+			// a lone brace is not a block.
+			continue
+		}
+
+		if errors[0].Code != diag.CodeUnterminatedBlock {
+			t.Errorf("wrong code for %q, expected = %q, got = %q", input, diag.CodeUnterminatedBlock, errors[0].Code)
+		}
+
+		if len(errors[0].Related) != 1 || errors[0].Fix == nil {
+			t.Errorf("expected a related note and a fix for %q. got = %+v", input, errors[0])
+		}
+	}
+}
+
+func TestMaxErrors(t *testing.T) {
+	input := strings.Repeat("let = 1;\n", parser.MaxErrors*2)
+
+	l := lexer.New(input)
+	p := parser.New(l)
+	p.Parse()
+
+	errors := p.Errors()
+	if len(errors) != parser.MaxErrors+1 {
+		t.Fatalf("wrong number of errors, expected = %d, got = %d", parser.MaxErrors+1, len(errors))
+	}
+
+	last := errors[len(errors)-1]
+	if last.Code != diag.CodeTooManyErrors {
+		t.Errorf("wrong code of the last error, expected = %q, got = %q", diag.CodeTooManyErrors, last.Code)
+	}
+}
@@ -20,6 +20,7 @@ package parser
 
 import (
 	"github.com/suenchunyu/snow-lang/internal/ast"
+	"github.com/suenchunyu/snow-lang/internal/diag"
 	"github.com/suenchunyu/snow-lang/internal/token"
 )
 
@@ -29,6 +30,19 @@ func (p *Parser) parseStatement() ast.Statement {
 		return p.parseLetStatement()
 	case token.FlagReturn:
 		return p.parseReturnStatement()
+	case token.FlagFunction:
+		if p.peekTokenIs(token.FlagIdent) {
+			return p.parseFunctionStatement()
+		}
+		return p.parseExpressionStatement()
+	case token.FlagFor:
+		return p.parseForStatement()
+	case token.FlagWhile:
+		return p.parseWhileStatement()
+	case token.FlagBreak:
+		return p.parseBreakStatement()
+	case token.FlagContinue:
+		return p.parseContinueStatement()
 	default:
 		return p.parseExpressionStatement()
 	}
@@ -38,10 +52,25 @@ func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 	block := &ast.BlockStatement{Token: p.cur}
 	block.Statements = make([]ast.Statement, 0)
 
+	p.depth += 1
+	defer func() {
+		p.depth -= 1
+	}()
+
 	p.nextToken()
 
 	for !p.curTokenIs(token.FlagRBrace) {
+		if p.curTokenIs(token.FlagEOF) {
+			p.unterminatedBlock(block)
+			return block
+		}
+
 		stmt := p.parseStatement()
+		if p.panicking {
+			p.synchronize()
+			continue
+		}
+
 		if stmt != nil {
 			block.Statements = append(block.Statements, stmt)
 		}
@@ -50,3 +79,17 @@ func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 
 	return block
 }
+
+func (p *Parser) unterminatedBlock(block *ast.BlockStatement) {
+	d := p.errorf(diag.CodeUnterminatedBlock, p.cur.Span, "unterminated block, expected } before EOF")
+	if d == nil {
+		return
+	}
+
+	d.Related = []diag.Note{{Message: "block starts here", Span: block.Token.Span}}
+	d.Fix = &diag.Fix{
+		Message:     "insert `}`",
+		Span:        token.Span{Start: p.cur.Span.Start, End: p.cur.Span.Start},
+		Replacement: "}",
+	}
+}
@@ -18,7 +18,11 @@
 
 package parser
 
-import "github.com/suenchunyu/snow-lang/internal/ast"
+import (
+	"github.com/suenchunyu/snow-lang/internal/ast"
+	"github.com/suenchunyu/snow-lang/internal/diag"
+	"github.com/suenchunyu/snow-lang/internal/token"
+)
 
 func (p *Parser) parseStringLiteral() ast.Expression {
 	return &ast.StringLiteral{
@@ -26,3 +30,41 @@ func (p *Parser) parseStringLiteral() ast.Expression {
 		Value: p.cur.Literal,
 	}
 }
+
+// parseTemplateLiteral parses a string embedding expressions `"a ${b} c"`,
+// which the lexer splits into a start, middle and end tokens around the
+// tokens of the expressions.
+func (p *Parser) parseTemplateLiteral() ast.Expression {
+	lit := &ast.TemplateLiteral{Token: p.cur, Parts: []string{p.cur.Literal}}
+
+	for {
+		if p.peekTokenIs(token.FlagTemplateMiddle) || p.peekTokenIs(token.FlagTemplateEnd) {
+			p.errorf(diag.CodeExpectedExpression, p.peek.Span, "empty expression in template string")
+			return nil
+		}
+
+		p.nextToken()
+		exp := p.parseExpression(Lowest)
+		if exp == nil {
+			return nil
+		}
+		lit.Expressions = append(lit.Expressions, exp)
+
+		switch {
+		case p.peekTokenIs(token.FlagTemplateEnd):
+			p.nextToken()
+			lit.Parts = append(lit.Parts, p.cur.Literal)
+			return lit
+		case p.peekTokenIs(token.FlagTemplateMiddle):
+			p.nextToken()
+			lit.Parts = append(lit.Parts, p.cur.Literal)
+		case p.peekTokenIs(token.FlagIllegal):
+			// already reported by the lexer.
+			p.panicking = true
+			return nil
+		default:
+			p.errorf(diag.CodeUnexpectedToken, p.peek.Span, "expected } to close the template expression, got %s instead", p.peek.Flag)
+			return nil
+		}
+	}
+}
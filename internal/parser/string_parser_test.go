@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/suenchunyu/snow-lang/internal/ast"
+	"github.com/suenchunyu/snow-lang/internal/diag"
 	"github.com/suenchunyu/snow-lang/internal/lexer"
 	"github.com/suenchunyu/snow-lang/internal/parser"
 )
@@ -44,3 +45,73 @@ func TestStringLiteralExpression(t *testing.T) {
 		t.Errorf("literal.Value not %q. got = %q", "hello world", literal.Value)
 	}
 }
+
+func TestTemplateLiteralParsing(t *testing.T) {
+	tests := []struct {
+		input       string
+		parts       []string
+		expressions []string
+	}{
+		{`"a ${b} c"`, []string{"a ", " c"}, []string{"b"}},
+		{`"${x + 1}"`, []string{"", ""}, []string{"(x + 1)"}},
+		{`"Hello ${user.name}, you have ${count + 1} items"`, []string{"Hello ", ", you have ", " items"}, []string{"(user.name)", "(count + 1)"}},
+		{`"${ {"k": 1}["k"] }!"`, []string{"", "!"}, []string{"({k:1}[k])"}},
+		{`"outer ${ "inner ${x}" }"`, []string{"outer ", ""}, []string{"inner ${x}"}},
+	}
+
+	for _, tt := range tests {
+		p := parser.New(lexer.New(tt.input))
+		program := p.Parse()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		literal, ok := stmt.Expression.(*ast.TemplateLiteral)
+		if !ok {
+			t.Fatalf("exp not *ast.TemplateLiteral. got = %T", stmt.Expression)
+		}
+
+		if len(literal.Parts) != len(tt.parts) || len(literal.Expressions) != len(tt.expressions) {
+			t.Fatalf("%s: wrong number of parts, got = %q and %d expressions", tt.input, literal.Parts, len(literal.Expressions))
+		}
+
+		for idx, part := range tt.parts {
+			if literal.Parts[idx] != part {
+				t.Errorf("%s: parts[%d] wrong, expected = %q, got = %q", tt.input, idx, part, literal.Parts[idx])
+			}
+		}
+
+		for idx, exp := range tt.expressions {
+			if literal.Expressions[idx].String() != exp {
+				t.Errorf("%s: expressions[%d] wrong, expected = %q, got = %q", tt.input, idx, exp, literal.Expressions[idx].String())
+			}
+		}
+	}
+}
+
+func TestInvalidTemplateLiterals(t *testing.T) {
+	tests := []struct {
+		input    string
+		code     diag.Code
+		expected string
+	}{
+		{`"a ${} b"`, diag.CodeExpectedExpression, "1:6: empty expression in template string"},
+		{`"a ${b c} d"`, diag.CodeUnexpectedToken, "1:8: expected } to close the template expression, got IDENT instead"},
+		{`"a ${b} c`, diag.CodeUnterminatedString, "1:7: unterminated string literal"},
+		{`"a ${b`, diag.CodeUnexpectedToken, "1:7: expected } to close the template expression, got EOF instead"},
+	}
+
+	for _, tt := range tests {
+		p := parser.New(lexer.New(tt.input))
+		p.Parse()
+
+		errors := p.Errors()
+		if len(errors) == 0 {
+			t.Errorf("%s: no error reported", tt.input)
+			continue
+		}
+
+		if errors[0].Code != tt.code || errors[0].Error() != tt.expected {
+			t.Errorf("%s: wrong error, expected = %s %q, got = %s %q", tt.input, tt.code, tt.expected, errors[0].Code, errors[0].Error())
+		}
+	}
+}
@@ -0,0 +1,58 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package parser
+
+import "github.com/suenchunyu/snow-lang/internal/ast"
+
+// markTailCalls marks the calls of block whose value is returned as is by the
+// enclosing function: the value of `return` statements, and the last
+// expression of the block if tail is set, looking into `if` branches. The
+// bodies of nested functions are marked when they're parsed.
+func markTailCalls(block *ast.BlockStatement, tail bool) {
+	if block == nil {
+		return
+	}
+
+	for idx, stmt := range block.Statements {
+		last := tail && idx == len(block.Statements)-1
+
+		switch stmt := stmt.(type) {
+		case *ast.ReturnStatement:
+			markTailExpression(stmt.ReturnValue, true)
+		case *ast.ExpressionStatement:
+			markTailExpression(stmt.Expression, last)
+		case *ast.ForStatement:
+			markTailCalls(stmt.Body, false)
+		case *ast.ForInStatement:
+			markTailCalls(stmt.Body, false)
+		case *ast.WhileStatement:
+			markTailCalls(stmt.Body, false)
+		}
+	}
+}
+
+func markTailExpression(exp ast.Expression, tail bool) {
+	switch exp := exp.(type) {
+	case *ast.CallExpression:
+		exp.Tail = tail
+	case *ast.IfExpression:
+		markTailCalls(exp.Consequence, tail)
+		markTailCalls(exp.Alternative, tail)
+	}
+}
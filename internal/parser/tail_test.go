@@ -0,0 +1,100 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/suenchunyu/snow-lang/internal/ast"
+	"github.com/suenchunyu/snow-lang/internal/lexer"
+	"github.com/suenchunyu/snow-lang/internal/parser"
+)
+
+func TestTailCallMarking(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected map[string]bool
+	}{
+		{"fn f(n) { g(n) }", map[string]bool{"g(n)": true}},
+		{"fn f(n) { return g(n); h(n) }", map[string]bool{"g(n)": true, "h(n)": true}},
+		{"fn f(n) { g(n); h(n) }", map[string]bool{"g(n)": false, "h(n)": true}},
+		{"fn f(n) { 1 + g(n) }", map[string]bool{"g(n)": false}},
+		{"fn f(n) { let x = g(n); x }", map[string]bool{"g(n)": false}},
+		{"fn f(n) { if (n) { g(n) } else { h(n) } }", map[string]bool{"g(n)": true, "h(n)": true}},
+		{"fn f(n) { if (n) { g(n) }; h(n) }", map[string]bool{"g(n)": false, "h(n)": true}},
+		{"fn f(n) { while (n) { g(n) } }", map[string]bool{"g(n)": false}},
+		{"fn f(n) { while (n) { return g(n) } }", map[string]bool{"g(n)": true}},
+		{"fn f(n) { fn() { g(n) }; h(n) }", map[string]bool{"g(n)": true, "h(n)": true}},
+		{"g(n)", map[string]bool{"g(n)": false}},
+		{"return g(n)", map[string]bool{"g(n)": false}},
+	}
+
+	for _, tt := range tests {
+		p := parser.New(lexer.New(tt.input))
+		program := p.Parse()
+		checkParserErrors(t, p)
+
+		calls := make(map[string]bool)
+		for _, stmt := range program.Statements {
+			collectCalls(stmt, calls)
+		}
+
+		for call, tail := range tt.expected {
+			got, ok := calls[call]
+			if !ok {
+				t.Errorf("call %s not found in %q", call, tt.input)
+				continue
+			}
+			if got != tail {
+				t.Errorf("wrong tail flag of %s in %q, expected = %t, got = %t", call, tt.input, tail, got)
+			}
+		}
+	}
+}
+
+// collectCalls records the tail flag of the calls in node by their source.
+func collectCalls(node ast.Node, calls map[string]bool) {
+	switch node := node.(type) {
+	case *ast.BlockStatement:
+		for _, stmt := range node.Statements {
+			collectCalls(stmt, calls)
+		}
+	case *ast.ExpressionStatement:
+		collectCalls(node.Expression, calls)
+	case *ast.ReturnStatement:
+		collectCalls(node.ReturnValue, calls)
+	case *ast.LetStatement:
+		collectCalls(node.Value, calls)
+	case *ast.WhileStatement:
+		collectCalls(node.Body, calls)
+	case *ast.FunctionStatement:
+		collectCalls(node.Function.Body, calls)
+	case *ast.FunctionLiteral:
+		collectCalls(node.Body, calls)
+	case *ast.IfExpression:
+		collectCalls(node.Consequence, calls)
+		if node.Alternative != nil {
+			collectCalls(node.Alternative, calls)
+		}
+	case *ast.InfixExpression:
+		collectCalls(node.Right, calls)
+	case *ast.CallExpression:
+		calls[node.String()] = node.Tail
+	}
+}
@@ -19,10 +19,11 @@
 package parser
 
 import (
-	"fmt"
+	"math/big"
 	"strconv"
 
 	"github.com/suenchunyu/snow-lang/internal/ast"
+	"github.com/suenchunyu/snow-lang/internal/diag"
 	"github.com/suenchunyu/snow-lang/internal/token"
 )
 
@@ -44,9 +45,28 @@ func (p *Parser) parseIntegerLiteral() ast.Expression {
 	lit := &ast.IntegerLiteral{Token: p.cur}
 
 	value, err := strconv.ParseInt(p.cur.Literal, 0, 64)
+	if err == nil {
+		lit.Value = value
+		return lit
+	}
+
+	large, ok := new(big.Int).SetString(p.cur.Literal, 0)
+	if !ok {
+		p.errorf(diag.CodeInvalidInteger, p.cur.Span, "could not parse %q as integer", p.cur.Literal)
+		return nil
+	}
+
+	lit.Big = large
+
+	return lit
+}
+
+func (p *Parser) parseFloatLiteral() ast.Expression {
+	lit := &ast.FloatLiteral{Token: p.cur}
+
+	value, err := strconv.ParseFloat(p.cur.Literal, 64)
 	if err != nil {
-		msg := fmt.Sprintf("could not parse %q as integer", p.cur.Literal)
-		p.errors = append(p.errors, msg)
+		p.errorf(diag.CodeInvalidFloat, p.cur.Span, "could not parse %q as float", p.cur.Literal)
 		return nil
 	}
 
@@ -23,8 +23,9 @@ import (
 	"fmt"
 	"io"
 	"os/user"
-	"runtime"
 
+	"github.com/suenchunyu/snow-lang/internal/color"
+	"github.com/suenchunyu/snow-lang/internal/diag"
 	"github.com/suenchunyu/snow-lang/internal/eval"
 	"github.com/suenchunyu/snow-lang/internal/lexer"
 	"github.com/suenchunyu/snow-lang/internal/object"
@@ -42,46 +43,20 @@ const (
 `
 )
 
-var (
-	reset  = "\033[0m"
-	red    = "\033[31m"
-	green  = "\033[32m"
-	yellow = "\033[33m"
-	blue   = "\033[34m"
-	purple = "\033[35m"
-	cyan   = "\033[36m"
-	gray   = "\033[37m"
-	white  = "\033[97m"
-)
-
-func init() {
-	if runtime.GOOS == "windows" {
-		reset = ""
-		red = ""
-		green = ""
-		yellow = ""
-		blue = ""
-		purple = ""
-		cyan = ""
-		gray = ""
-		white = ""
-	}
-}
-
 func Start(in io.Reader, out io.Writer) {
 	scanner := bufio.NewScanner(in)
 	env := object.NewEnv()
 
-	_, _ = io.WriteString(out, green+Logo+reset)
+	_, _ = io.WriteString(out, color.Green+Logo+color.Reset)
 	u, err := user.Current()
 	if err != nil {
 		panic(err)
 	}
-	_, _ = io.WriteString(out, blue+fmt.Sprintf("Hello %s! This is the Snow programming language!\n", u.Name)+reset)
-	_, _ = io.WriteString(out, blue+fmt.Sprintf("Fell free to type in commands!\n")+reset)
+	_, _ = io.WriteString(out, color.Blue+fmt.Sprintf("Hello %s! This is the Snow programming language!\n", u.Name)+color.Reset)
+	_, _ = io.WriteString(out, color.Blue+fmt.Sprintf("Fell free to type in commands!\n")+color.Reset)
 
 	for {
-		fmt.Printf(yellow + Prompt + reset)
+		fmt.Printf(color.Yellow + Prompt + color.Reset)
 		scanned := scanner.Scan()
 		if !scanned {
 			return
@@ -93,14 +68,14 @@ func Start(in io.Reader, out io.Writer) {
 
 		program := p.Parse()
 		if len(p.Errors()) != 0 {
-			printErrors(out, p.Errors())
+			printErrors(out, line, p.Errors())
 			continue
 		}
 
 		evaluated := eval.Eval(program, env)
 		if evaluated != nil {
-			if evaluated.Type() == object.TypeError {
-				printErrors(out, []string{evaluated.Inspect()})
+			if err, ok := evaluated.(*object.Error); ok {
+				printErrors(out, line, []*diag.Diagnostic{err.Diagnostic()})
 				continue
 			}
 			_, _ = io.WriteString(out, evaluated.Inspect())
@@ -109,9 +84,7 @@ func Start(in io.Reader, out io.Writer) {
 	}
 }
 
-func printErrors(out io.Writer, errors []string) {
-	_, _ = io.WriteString(out, red+"Oops! We ran into some awful things here!\n"+reset)
-	for _, errMsg := range errors {
-		_, _ = io.WriteString(out, red+fmt.Sprintf("\t%s\n", errMsg)+reset)
-	}
+func printErrors(out io.Writer, line string, errors []*diag.Diagnostic) {
+	_, _ = io.WriteString(out, color.Red+"Oops! We ran into some awful things here!\n"+color.Reset)
+	diag.Render(out, line, errors, true)
 }
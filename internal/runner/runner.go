@@ -0,0 +1,118 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package runner
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/suenchunyu/snow-lang/internal/color"
+	"github.com/suenchunyu/snow-lang/internal/diag"
+	"github.com/suenchunyu/snow-lang/internal/eval"
+	"github.com/suenchunyu/snow-lang/internal/lexer"
+	"github.com/suenchunyu/snow-lang/internal/object"
+	"github.com/suenchunyu/snow-lang/internal/parser"
+)
+
+const (
+	ExitOK    = 0
+	ExitError = 1
+)
+
+// Format selects how errors are reported.
+type Format uint8
+
+const (
+	FormatHuman Format = iota
+	FormatJSON
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatJSON:
+		return "json"
+	default:
+		return "human"
+	}
+}
+
+func ParseFormat(s string) (Format, error) {
+	switch s {
+	case "human":
+		return FormatHuman, nil
+	case "json":
+		return FormatJSON, nil
+	default:
+		return FormatHuman, fmt.Errorf("unknown error format %q, want human or json", s)
+	}
+}
+
+// Run reads the script located at path, evaluates it and reports any
+// parse or runtime errors to errOut. args holds the command line arguments
+// following the script path, they are bound to the global `args` array.
+// The returned value is meant to be used as the exit status of the process.
+func Run(path string, args []string, errOut io.Writer, format Format) int {
+	source, err := ioutil.ReadFile(path)
+	if err != nil {
+		_, _ = fmt.Fprintf(errOut, "snow: %s\n", err)
+		return ExitError
+	}
+	return RunSource(path, string(source), args, errOut, format)
+}
+
+// RunSource evaluates source as if it was read from the file name.
+func RunSource(name, source string, args []string, errOut io.Writer, format Format) int {
+	l := lexer.NewFile(name, source)
+	p := parser.New(l)
+
+	program := p.Parse()
+	if len(p.Errors()) != 0 {
+		report(errOut, source, p.Errors(), format)
+		return ExitError
+	}
+
+	env := object.NewEnv()
+	env.Set("args", newArgs(args))
+
+	evaluated := eval.Eval(program, env)
+	if err, ok := evaluated.(*object.Error); ok {
+		report(errOut, source, []*diag.Diagnostic{err.Diagnostic()}, format)
+		return ExitError
+	}
+
+	return ExitOK
+}
+
+func newArgs(args []string) *object.Array {
+	elements := make([]object.Object, 0, len(args))
+	for _, arg := range args {
+		elements = append(elements, &object.String{Value: arg})
+	}
+	return &object.Array{Elements: elements}
+}
+
+func report(errOut io.Writer, source string, diags []*diag.Diagnostic, format Format) {
+	switch format {
+	case FormatJSON:
+		_ = diag.RenderJSON(errOut, diags)
+	default:
+		diag.Render(errOut, source, diags, color.IsTerminal(errOut))
+	}
+}
@@ -0,0 +1,103 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package runner_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/suenchunyu/snow-lang/internal/runner"
+)
+
+func TestRunSource(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+		errOut   string
+	}{
+		{"let a = 1; a + 1;", runner.ExitOK, ""},
+		{"#!/usr/bin/env snow\nlet a = 1;", runner.ExitOK, ""},
+		{"let = 1;", runner.ExitError, "error[unexpected-token]: expected next token to be IDENT, got = instead\n --> test.snow:1:5"},
+		{"5 + true;", runner.ExitError, "error[type-mismatch]: type mismatch: Integer + Boolean\n --> test.snow:1:3"},
+		{`if (len(args) != 2) { args[5] }`, runner.ExitOK, ""},
+		{`args[0] + args[1] + 1`, runner.ExitError, "type mismatch: String + Integer"},
+		{"fn f() { nope }\nf()", runner.ExitError, "    at f (test.snow:2:1)\n"},
+	}
+
+	for _, tt := range tests {
+		var errOut bytes.Buffer
+		code := runner.RunSource("test.snow", tt.input, []string{"a", "b"}, &errOut, runner.FormatHuman)
+		if code != tt.expected {
+			t.Errorf("wrong exit status for %q. expected = %d, got = %d", tt.input, tt.expected, code)
+		}
+
+		if tt.errOut != "" && !strings.Contains(errOut.String(), tt.errOut) {
+			t.Errorf("error output does not contain %q. got = %q", tt.errOut, errOut.String())
+		}
+	}
+}
+
+func TestRunSourceJSON(t *testing.T) {
+	var errOut bytes.Buffer
+	code := runner.RunSource("test.snow", "let = 1;", nil, &errOut, runner.FormatJSON)
+	if code != runner.ExitError {
+		t.Fatalf("wrong exit status. expected = %d, got = %d", runner.ExitError, code)
+	}
+
+	var d struct {
+		Code string `json:"code"`
+		Span struct {
+			File  string `json:"file"`
+			Start struct {
+				Line   int `json:"line"`
+				Column int `json:"column"`
+			} `json:"start"`
+		} `json:"span"`
+	}
+	if err := json.NewDecoder(&errOut).Decode(&d); err != nil {
+		t.Fatalf("error output is not a JSON line: %s. got = %q", err, errOut.String())
+	}
+
+	if d.Code != "unexpected-token" || d.Span.File != "test.snow" || d.Span.Start.Line != 1 || d.Span.Start.Column != 5 {
+		t.Errorf("unexpected diagnostic. got = %+v", d)
+	}
+}
+
+func TestRunMissingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var errOut bytes.Buffer
+	code := runner.Run(filepath.Join(dir, "missing.snow"), nil, &errOut, runner.FormatHuman)
+	if code != runner.ExitError {
+		t.Errorf("wrong exit status. expected = %d, got = %d", runner.ExitError, code)
+	}
+
+	if !strings.HasPrefix(errOut.String(), "snow: ") {
+		t.Errorf("unexpected error output. got = %q", errOut.String())
+	}
+}
@@ -18,6 +18,45 @@
 
 package token
 
+import "fmt"
+
+// Pos describes a location in the source text. Line and Column start at 1,
+// Offset is the byte offset starting at 0. The zero value means that the
+// position is unknown.
+type Pos struct {
+	File   string
+	Offset int
+	Line   int
+	Column int
+}
+
+func (p Pos) IsValid() bool {
+	return p.Line > 0
+}
+
+func (p Pos) String() string {
+	if !p.IsValid() {
+		if p.File != "" {
+			return p.File
+		}
+		return "-"
+	}
+	if p.File != "" {
+		return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+	}
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// Span is the half-open range [Start, End) of source text.
+type Span struct {
+	Start Pos
+	End   Pos
+}
+
+func (s Span) String() string {
+	return s.Start.String()
+}
+
 type Flag uint8
 
 const (
@@ -26,16 +65,32 @@ const (
 
 	FlagIdent
 	FlagInt
+	FlagFloat
 
 	FlagAssign
+	FlagPlusAssign
+	FlagMinusAssign
+	FlagAsteriskAssign
+	FlagSlashAssign
+	FlagPercentAssign
 	FlagPlus
 	FlagMinus
 	FlagEM
 	FlagAsterisk
 	FlagSlash
+	FlagPercent
+	FlagAmpersand
+	FlagPipe
+	FlagCaret
+	FlagTilde
+	FlagShiftLeft
+	FlagShiftRight
 
 	FlagComma
 	FlagSemicolon
+	FlagColon
+	FlagEllipsis
+	FlagDot
 
 	FlagLParen
 	FlagRParen
@@ -45,9 +100,13 @@ const (
 	FlagRBracket
 	FlagLessThan
 	FlagGreaterThan
+	FlagLessEqual
+	FlagGreaterEqual
 
 	FlagEqual
 	FlagNotEqual
+	FlagAnd
+	FlagOr
 
 	FlagFunction
 	FlagLet
@@ -57,6 +116,14 @@ const (
 	FlagElse
 	FlagReturn
 	FlagString
+	FlagTemplateStart
+	FlagTemplateMiddle
+	FlagTemplateEnd
+	FlagFor
+	FlagWhile
+	FlagIn
+	FlagBreak
+	FlagContinue
 )
 
 func (f Flag) String() string {
@@ -69,8 +136,20 @@ func (f Flag) String() string {
 		return "IDENT"
 	case FlagInt:
 		return "INT"
+	case FlagFloat:
+		return "FLOAT"
 	case FlagAssign:
 		return "="
+	case FlagPlusAssign:
+		return "+="
+	case FlagMinusAssign:
+		return "-="
+	case FlagAsteriskAssign:
+		return "*="
+	case FlagSlashAssign:
+		return "/="
+	case FlagPercentAssign:
+		return "%="
 	case FlagPlus:
 		return "+"
 	case FlagMinus:
@@ -81,10 +160,30 @@ func (f Flag) String() string {
 		return "*"
 	case FlagSlash:
 		return "/"
+	case FlagPercent:
+		return "%"
+	case FlagAmpersand:
+		return "&"
+	case FlagPipe:
+		return "|"
+	case FlagCaret:
+		return "^"
+	case FlagTilde:
+		return "~"
+	case FlagShiftLeft:
+		return "<<"
+	case FlagShiftRight:
+		return ">>"
 	case FlagComma:
 		return ","
 	case FlagSemicolon:
 		return ";"
+	case FlagColon:
+		return ":"
+	case FlagEllipsis:
+		return "..."
+	case FlagDot:
+		return "."
 	case FlagLParen:
 		return "("
 	case FlagRParen:
@@ -101,10 +200,18 @@ func (f Flag) String() string {
 		return "<"
 	case FlagGreaterThan:
 		return ">"
+	case FlagLessEqual:
+		return "<="
+	case FlagGreaterEqual:
+		return ">="
 	case FlagEqual:
 		return "=="
 	case FlagNotEqual:
 		return "!="
+	case FlagAnd:
+		return "&&"
+	case FlagOr:
+		return "||"
 	case FlagFunction:
 		return "FUNCTION"
 	case FlagLet:
@@ -121,24 +228,65 @@ func (f Flag) String() string {
 		return "RETURN"
 	case FlagString:
 		return "STRING"
+	case FlagTemplateStart:
+		return "TEMPLATE_START"
+	case FlagTemplateMiddle:
+		return "TEMPLATE_MIDDLE"
+	case FlagTemplateEnd:
+		return "TEMPLATE_END"
+	case FlagFor:
+		return "FOR"
+	case FlagWhile:
+		return "WHILE"
+	case FlagIn:
+		return "IN"
+	case FlagBreak:
+		return "BREAK"
+	case FlagContinue:
+		return "CONTINUE"
 	default:
 		return "ILLEGAL"
 	}
 }
 
 var keywords = map[string]Flag{
-	"fn":     FlagFunction,
-	"let":    FlagLet,
-	"true":   FlagTrue,
-	"false":  FlagFalse,
-	"if":     FlagIf,
-	"else":   FlagElse,
-	"return": FlagReturn,
+	"fn":       FlagFunction,
+	"let":      FlagLet,
+	"true":     FlagTrue,
+	"false":    FlagFalse,
+	"if":       FlagIf,
+	"else":     FlagElse,
+	"return":   FlagReturn,
+	"for":      FlagFor,
+	"while":    FlagWhile,
+	"in":       FlagIn,
+	"break":    FlagBreak,
+	"continue": FlagContinue,
+}
+
+// Comment is a line or block comment, Text includes its delimiters.
+type Comment struct {
+	Text string
+	Span Span
+}
+
+// IsBlock reports whether the comment is a /* */ comment.
+func (c Comment) IsBlock() bool {
+	return len(c.Text) >= 2 && c.Text[1] == '*'
 }
 
 type Token struct {
 	Flag    Flag
 	Literal string
+	Span    Span
+	// Leading holds the comments between the previous token and this one,
+	// they don't affect parsing but are kept for tools reproducing the source.
+	Leading []Comment
+}
+
+// Pos returns the position of the first character of the token.
+func (t *Token) Pos() Pos {
+	return t.Span.Start
 }
 
 func New(flag Flag, ch byte) *Token {
@@ -0,0 +1,64 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package snow
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/suenchunyu/snow-lang/internal/diag"
+)
+
+// Diagnostic describes a syntax or runtime error, with its location in the
+// source.
+type Diagnostic = diag.Diagnostic
+
+// ErrBudgetExceeded is matched by the errors of evaluations running out of
+// their step budget.
+var ErrBudgetExceeded = errors.New("snow: step budget exceeded")
+
+// Error is returned for scripts failing to parse or evaluate.
+type Error struct {
+	// Source is the evaluated source code.
+	Source      string
+	Diagnostics []*Diagnostic
+
+	// cause is the reason an evaluation was stopped, if it was.
+	cause error
+}
+
+func (e *Error) Error() string {
+	if len(e.Diagnostics) == 1 {
+		return e.Diagnostics[0].Error()
+	}
+	return fmt.Sprintf("%s (and %d more errors)", e.Diagnostics[0].Error(), len(e.Diagnostics)-1)
+}
+
+// Unwrap returns ErrBudgetExceeded or the error of the context for stopped
+// evaluations, nil otherwise.
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Render writes the diagnostics in the same form as the snow command does,
+// with the offending source lines.
+func (e *Error) Render(w io.Writer, colored bool) {
+	diag.Render(w, e.Source, e.Diagnostics, colored)
+}
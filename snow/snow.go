@@ -0,0 +1,252 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package snow embeds the Snow interpreter into Go programs.
+//
+//	interp := snow.New()
+//	_ = interp.Set("limit", 10)
+//	v, err := interp.Eval(ctx, "limit * 2")
+//
+// An Interpreter keeps its global bindings between evaluations, it must not
+// be used by multiple goroutines at the same time.
+package snow
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/suenchunyu/snow-lang/internal/diag"
+	"github.com/suenchunyu/snow-lang/internal/eval"
+	"github.com/suenchunyu/snow-lang/internal/host"
+	"github.com/suenchunyu/snow-lang/internal/lexer"
+	"github.com/suenchunyu/snow-lang/internal/object"
+	"github.com/suenchunyu/snow-lang/internal/parser"
+)
+
+// Value is a Snow value, use ToValue and FromValue to convert between Go
+// and Snow values.
+type Value = object.Object
+
+// Func is a Go function callable from scripts. A returned error is raised
+// as a runtime error in the script.
+type Func = host.Func
+
+type Interpreter struct {
+	env       *object.Environment
+	evaluator *eval.Evaluator
+
+	evalOpts []eval.Option
+	stdout   io.Writer
+}
+
+type Option func(i *Interpreter)
+
+// WithCheckedArithmetic makes integer overflow a runtime error instead of
+// promoting the result to arbitrary precision.
+func WithCheckedArithmetic() Option {
+	return func(i *Interpreter) {
+		i.evalOpts = append(i.evalOpts, eval.WithCheckedArithmetic())
+	}
+}
+
+// WithStepBudget limits each evaluation to n steps, a step is a function
+// call, a loop iteration or a block statement. Evaluations taking more steps
+// fail with an error matching ErrBudgetExceeded.
+func WithStepBudget(n int) Option {
+	return func(i *Interpreter) {
+		i.evalOpts = append(i.evalOpts, eval.WithStepBudget(n))
+	}
+}
+
+// WithMaxCallDepth limits the number of nested function calls to n, it's
+// 10000 by default and when n isn't positive.
+func WithMaxCallDepth(n int) Option {
+	return func(i *Interpreter) {
+		i.evalOpts = append(i.evalOpts, eval.WithMaxCallDepth(n))
+	}
+}
+
+// WithStdout redirects the output of the `print` builtin to w, it's the
+// standard output by default.
+func WithStdout(w io.Writer) Option {
+	return func(i *Interpreter) {
+		i.stdout = w
+	}
+}
+
+func New(opts ...Option) *Interpreter {
+	i := &Interpreter{env: object.NewEnv()}
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	i.evaluator = eval.New(i.evalOpts...)
+	if i.stdout != nil {
+		i.env.Set("print", eval.NewPrint(i.stdout))
+	}
+
+	return i
+}
+
+// Eval evaluates source and returns the value of its last statement. Syntax
+// and runtime errors are returned as *Error. The evaluation stops once ctx is
+// done, the returned error matches ctx.Err() then.
+func (i *Interpreter) Eval(ctx context.Context, source string) (Value, error) {
+	return i.eval(ctx, "", source)
+}
+
+// EvalFile evaluates the script located at path, positions in errors refer
+// to the file.
+func (i *Interpreter) EvalFile(ctx context.Context, path string) (Value, error) {
+	source, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return i.eval(ctx, path, string(source))
+}
+
+func (i *Interpreter) eval(ctx context.Context, file, source string) (Value, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	p := parser.New(lexer.NewFile(file, source))
+	program := p.Parse()
+	if len(p.Errors()) != 0 {
+		return nil, &Error{Source: source, Diagnostics: p.Errors()}
+	}
+
+	result := i.evaluator.EvalContext(ctx, program, i.env)
+	switch result := result.(type) {
+	case nil:
+		return eval.Null, nil
+	case *object.Error:
+		err := &Error{Source: source, Diagnostics: []*Diagnostic{result.Diagnostic()}}
+		switch result.Code {
+		case diag.CodeCancelled:
+			err.cause = ctx.Err()
+		case diag.CodeBudgetExceeded:
+			err.cause = ErrBudgetExceeded
+		}
+		return nil, err
+	default:
+		return result, nil
+	}
+}
+
+// Get returns the value of the global name.
+func (i *Interpreter) Get(name string) (Value, bool) {
+	return i.env.Get(name)
+}
+
+// Set binds the global name to v converted by ToValue.
+func (i *Interpreter) Set(name string, v interface{}) error {
+	value, err := ToValue(v)
+	if err != nil {
+		return fmt.Errorf("snow: set %s: %w", name, err)
+	}
+	i.env.Set(name, value)
+	return nil
+}
+
+// RegisterFunc makes fn callable from scripts as the global function name.
+// fn may be a Func which receives the arguments as they are, or any other Go
+// function, like
+//
+//	func(s string, n int) (string, error)
+//
+// whose arguments are converted to the parameter types: integers to any
+// integer type they fit into and to *big.Int, numbers to float types, arrays
+// to slices, hashes to maps and null to nil pointers, slices and maps.
+// Parameters of type interface{} receive the argument converted by FromValue
+// and parameters of type Value the argument itself. The function may return
+// nothing, a value, an error, or a value and an error; a non-nil error is
+// raised as a runtime error in the script.
+func (i *Interpreter) RegisterFunc(name string, fn interface{}) error {
+	switch fn := fn.(type) {
+	case Func:
+		i.env.Set(name, host.NewBuiltin(fn))
+	case func(args ...Value) (Value, error):
+		i.env.Set(name, host.NewBuiltin(fn))
+	default:
+		builtin, err := host.Bind(name, fn)
+		if err != nil {
+			return fmt.Errorf("snow: register %s: %w", name, err)
+		}
+		i.env.Set(name, builtin)
+	}
+	return nil
+}
+
+// NewObject exposes v, a struct or a pointer to one, to scripts. They read
+// its fields by `value.Field` and call its methods by `value.Method(args)`,
+// fields are converted by ToValue and methods are bound like RegisterFunc
+// does. members is the allow-list of the accessible fields and methods, all
+// exported ones are accessible if it's empty. Structs held by fields, also
+// in slices, maps and interfaces, are exposed the same way and share the
+// allow-list.
+//
+//	req, err := snow.NewObject(&request, "Method", "Path", "Header")
+//	_ = interp.Set("req", req)
+func NewObject(v interface{}, members ...string) (Value, error) {
+	o, err := host.NewObject(v, members...)
+	if err != nil {
+		return nil, fmt.Errorf("snow: %w", err)
+	}
+	return o, nil
+}
+
+// ToValue converts a Go value to a Snow value:
+//
+//	nil, nil pointers     null
+//	bool                  boolean
+//	signed integers       integer
+//	unsigned integers     integer
+//	*big.Int              integer
+//	floats                float
+//	string                string
+//	slices and arrays     array
+//	maps                  hash, ordered by the formatted keys
+//	Func                  function
+//	Value                 the value itself
+//
+// Pointers and interfaces are converted to the value they refer to. Values
+// which contain themselves can't be converted.
+func ToValue(v interface{}) (Value, error) {
+	return host.ToObject(v)
+}
+
+// FromValue converts a Snow value to a Go value:
+//
+//	null        nil
+//	boolean     bool
+//	integer     int64, or *big.Int beyond the range of an int64
+//	float       float64
+//	string      string
+//	array       []interface{}
+//	hash        map[string]interface{} if all keys are strings,
+//	            map[interface{}]interface{} otherwise
+//	object      the Go value passed to NewObject
+//
+// Functions, and arrays or hashes which contain themselves, can't be
+// converted.
+func FromValue(v Value) (interface{}, error) {
+	return host.FromObject(v)
+}
@@ -0,0 +1,412 @@
+/*
+ * Snow-Lang, A Toy-Level Programming Language.
+ * Copyright (C) 2021  Suen ChunYu<mailto:sunzhenyucn@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package snow_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/suenchunyu/snow-lang/snow"
+)
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"1 + 2", int64(3)},
+		{`"snow" + "flake"`, "snowflake"},
+		{"[1, 2 * 2]", []interface{}{int64(1), int64(4)}},
+		{`{"a": true}`, map[string]interface{}{"a": true}},
+		{"let a = 1;", nil},
+	}
+
+	for _, tt := range tests {
+		interp := snow.New()
+
+		v, err := interp.Eval(context.Background(), tt.input)
+		if err != nil {
+			t.Errorf("Eval(%q) failed: %s", tt.input, err)
+			continue
+		}
+
+		got, err := snow.FromValue(v)
+		if err != nil {
+			t.Errorf("FromValue(%s) failed: %s", v.Inspect(), err)
+			continue
+		}
+
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("Eval(%q) wrong, expected = %#v, got = %#v", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestGlobalsPersist(t *testing.T) {
+	interp := snow.New()
+	ctx := context.Background()
+
+	if _, err := interp.Eval(ctx, "let total = 1; fn inc(n) { total += n }"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := interp.Eval(ctx, "inc(41)"); err != nil {
+		t.Fatal(err)
+	}
+
+	v, ok := interp.Get("total")
+	if !ok {
+		t.Fatal("global total is not defined")
+	}
+
+	if v.Inspect() != "42" {
+		t.Errorf("wrong value of total, expected = 42, got = %s", v.Inspect())
+	}
+
+	if _, ok := interp.Get("missing"); ok {
+		t.Error("Get reported an undefined global")
+	}
+}
+
+func TestSet(t *testing.T) {
+	interp := snow.New()
+
+	config := map[string]interface{}{
+		"name":  "snow",
+		"ports": []uint16{80, 443},
+		"debug": false,
+	}
+	if err := interp.Set("config", config); err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := interp.Eval(context.Background(), `if !config["debug"] { config["ports"][1] }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v.Inspect() != "443" {
+		t.Errorf("wrong value, expected = 443, got = %s", v.Inspect())
+	}
+
+	if err := interp.Set("ch", make(chan int)); err == nil {
+		t.Error("expected an error setting a channel")
+	}
+}
+
+func TestRegisterFunc(t *testing.T) {
+	interp := snow.New()
+
+	err := interp.RegisterFunc("sum", func(args ...snow.Value) (snow.Value, error) {
+		var total int64
+		for _, arg := range args {
+			v, err := snow.FromValue(arg)
+			if err != nil {
+				return nil, err
+			}
+			n, ok := v.(int64)
+			if !ok {
+				return nil, errors.New("sum: arguments must be integers")
+			}
+			total += n
+		}
+		return snow.ToValue(total)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := interp.Eval(context.Background(), "sum(1, 2, 3) * 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Inspect() != "12" {
+		t.Errorf("wrong value, expected = 12, got = %s", v.Inspect())
+	}
+
+	_, err = interp.Eval(context.Background(), `sum(1, "2")`)
+	if err == nil || !strings.Contains(err.Error(), "sum: arguments must be integers") {
+		t.Errorf("expected the error of the Go function, got = %v", err)
+	}
+
+	if err := interp.RegisterFunc("bad", 42); err == nil {
+		t.Error("expected an error registering a non function")
+	}
+}
+
+func TestErrors(t *testing.T) {
+	interp := snow.New()
+
+	_, err := interp.Eval(context.Background(), "let = 1;")
+	var snowErr *snow.Error
+	if !errors.As(err, &snowErr) {
+		t.Fatalf("error is not *snow.Error, got = %T (%v)", err, err)
+	}
+
+	if snowErr.Error() != "1:5: expected next token to be IDENT, got = instead" {
+		t.Errorf("wrong error, got = %q", snowErr.Error())
+	}
+
+	_, err = interp.Eval(context.Background(), "fn f() { 1 / 0 }\nf()")
+	if !errors.As(err, &snowErr) {
+		t.Fatalf("error is not *snow.Error, got = %T (%v)", err, err)
+	}
+
+	var out bytes.Buffer
+	snowErr.Render(&out, false)
+	if !strings.Contains(out.String(), "division by zero") || !strings.Contains(out.String(), "at f (2:1)") {
+		t.Errorf("wrong rendering, got = %q", out.String())
+	}
+}
+
+func TestEvalFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "rules.snow")
+	if err := ioutil.WriteFile(path, []byte("let limit = 10;\nlimit + nope"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = snow.New().EvalFile(context.Background(), path)
+	if err == nil || err.Error() != path+":2:9: undefined identifier: nope" {
+		t.Errorf("wrong error, got = %v", err)
+	}
+
+	if _, err := snow.New().EvalFile(context.Background(), filepath.Join(dir, "missing.snow")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestOptions(t *testing.T) {
+	var out bytes.Buffer
+	interp := snow.New(snow.WithStdout(&out), snow.WithCheckedArithmetic())
+
+	if _, err := interp.Eval(context.Background(), `print("hello", 1)`); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "hello\n1\n" {
+		t.Errorf("wrong output, got = %q", out.String())
+	}
+
+	_, err := interp.Eval(context.Background(), "9223372036854775807 + 1")
+	if err == nil || !strings.Contains(err.Error(), "integer overflow") {
+		t.Errorf("expected an overflow error, got = %v", err)
+	}
+}
+
+func TestPrintSelfReference(t *testing.T) {
+	var out bytes.Buffer
+	interp := snow.New(snow.WithStdout(&out))
+
+	input := `let a = [1]; a[0] = a; print(a); let h = {}; h["h"] = h; print(h);`
+	if _, err := interp.Eval(context.Background(), input); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "[[...]]\n{\"h\": {...}}\n" {
+		t.Errorf("wrong output, got = %q", out.String())
+	}
+
+	a, err := interp.Eval(context.Background(), "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := snow.FromValue(a); err == nil || !strings.Contains(err.Error(), "contains itself") {
+		t.Errorf("expected a cycle error, got = %v", err)
+	}
+}
+
+func TestCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := snow.New().Eval(ctx, "1"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got = %v", err)
+	}
+}
+
+func TestDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := snow.New().Eval(ctx, "while (true) {}")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got = %v", err)
+	}
+
+	var serr *snow.Error
+	if !errors.As(err, &serr) || serr.Diagnostics[0].Code != "cancelled" {
+		t.Errorf("expected a cancelled diagnostic, got = %v", err)
+	}
+}
+
+func TestStepBudget(t *testing.T) {
+	interp := snow.New(snow.WithStepBudget(1000))
+
+	_, err := interp.Eval(context.Background(), "fn loop() { loop() } loop()")
+	if !errors.Is(err, snow.ErrBudgetExceeded) {
+		t.Errorf("expected ErrBudgetExceeded, got = %v", err)
+	}
+
+	if _, err := interp.Eval(context.Background(), "let sum = 0; for x in [1, 2, 3] { sum += x }; sum"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestReentrantEval(t *testing.T) {
+	// reenter evaluates again while the outer evaluation runs, the outer
+	// one must keep its own deadline and step budget.
+	loop := func(ctx context.Context, interp *snow.Interpreter) error {
+		reenter := func() (int, error) {
+			_, err := interp.Eval(context.Background(), "1")
+			return 1, err
+		}
+		if err := interp.RegisterFunc("reenter", reenter); err != nil {
+			t.Fatal(err)
+		}
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := interp.Eval(ctx, "let n = 0; while (true) { n += reenter() }")
+			done <- err
+		}()
+
+		select {
+		case err := <-done:
+			return err
+		case <-time.After(5 * time.Second):
+			t.Fatal("the outer evaluation never stopped")
+			return nil
+		}
+	}
+
+	if err := loop(context.Background(), snow.New(snow.WithStepBudget(1000))); !errors.Is(err, snow.ErrBudgetExceeded) {
+		t.Errorf("expected ErrBudgetExceeded, got = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := loop(ctx, snow.New()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got = %v", err)
+	}
+}
+
+func TestMaxCallDepth(t *testing.T) {
+	interp := snow.New(snow.WithMaxCallDepth(100))
+
+	_, err := interp.Eval(context.Background(), "fn f(x) { 1 + f(x) } f(1)")
+	if err == nil || !strings.Contains(err.Error(), "maximum call depth exceeded") {
+		t.Errorf("expected a call depth error, got = %v", err)
+	}
+
+	// the interpreter is still usable afterwards.
+	if _, err := interp.Eval(context.Background(), "f"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRegisterGoFunc(t *testing.T) {
+	interp := snow.New()
+
+	if err := interp.RegisterFunc("repeat", strings.Repeat); err != nil {
+		t.Fatal(err)
+	}
+
+	err := interp.RegisterFunc("parse", func(s string) (int, error) {
+		if s == "" {
+			return 0, errors.New("parse: empty input")
+		}
+		return len(s), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := interp.Eval(context.Background(), `repeat("ab", parse("xyz"))`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Inspect() != "ababab" {
+		t.Errorf("wrong value, expected = ababab, got = %s", v.Inspect())
+	}
+
+	_, err = interp.Eval(context.Background(), `parse("")`)
+	if err == nil || err.Error() != "1:1: parse: empty input" {
+		t.Errorf("wrong error, got = %v", err)
+	}
+
+	_, err = interp.Eval(context.Background(), `repeat("ab", "3")`)
+	if err == nil || err.Error() != "1:1: argument 2 to repeat: cannot use String as int" {
+		t.Errorf("wrong error, got = %v", err)
+	}
+
+	if err := interp.RegisterFunc("pair", func() (int, int) { return 0, 0 }); err == nil {
+		t.Error("expected an error registering a function returning two values")
+	}
+}
+
+type user struct {
+	Name  string
+	Admin bool
+	token string
+}
+
+func (u *user) Greet(greeting string) string {
+	return greeting + ", " + u.Name
+}
+
+func TestNewObject(t *testing.T) {
+	interp := snow.New()
+
+	obj, err := snow.NewObject(&user{Name: "snow", token: "secret"}, "Name", "Greet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := interp.Set("user", obj); err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := interp.Eval(context.Background(), `user.Greet("Hello") + "!"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Inspect() != "Hello, snow!" {
+		t.Errorf("wrong value, expected = %q, got = %q", "Hello, snow!", v.Inspect())
+	}
+
+	_, err = interp.Eval(context.Background(), `user.Admin`)
+	if err == nil || err.Error() != "1:5: undefined property: Admin" {
+		t.Errorf("wrong error, got = %v", err)
+	}
+
+	if _, err := snow.NewObject(&user{}, "token"); err == nil {
+		t.Error("expected an error exposing an unexported field")
+	}
+}